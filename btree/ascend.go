@@ -0,0 +1,103 @@
+package btree
+
+// AllFunc calls fn for every element in the tree from least to greatest.
+// Iteration stops early when fn returns false.
+//
+// The complexity is O(n).
+func (bt *btree[K]) AllFunc(fn func(K) bool) {
+	if bt.root == nil {
+		return
+	}
+	bt.ascend(bt.root, nil, fn)
+}
+
+// AscendFunc calls fn for every element greater than or equal to from, from
+// least to greatest. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *btree[K]) AscendFunc(from K, fn func(K) bool) {
+	if bt.root == nil {
+		return
+	}
+	bt.ascend(bt.root, &from, fn)
+}
+
+// DescendFunc calls fn for every element less than or equal to from, from
+// greatest to least. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *btree[K]) DescendFunc(from K, fn func(K) bool) {
+	if bt.root == nil {
+		return
+	}
+	bt.descend(bt.root, &from, fn)
+}
+
+// RangeFunc calls fn for every element greater than or equal to lo and less
+// than hi, from least to greatest. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *btree[K]) RangeFunc(lo, hi K, fn func(K) bool) {
+	bt.AscendFunc(lo, func(e K) bool {
+		if 0 <= bt.cmp(e, hi) {
+			return false
+		}
+		return fn(e)
+	})
+}
+
+// ascend visits the subtree of n in order calling fn for each element. When
+// from is given elements less than from are skipped along with the subtrees
+// that can only contain elements less than from.
+//
+// Returns false when fn stopped the iteration.
+func (bt *btree[K]) ascend(n *node[K], from *K, fn func(K) bool) bool {
+	leaf := len(n.children) == 0
+	start := 0
+	if from != nil {
+		start, _ = n.search(*from, bt.cmp)
+	}
+	for i := start; i < len(n.elements); i++ {
+		e := n.elements[i]
+		if !leaf && !bt.ascend(n.children[i], from, fn) {
+			return false
+		}
+		// Everything after e is at least e, so there is nothing left to skip.
+		from = nil
+		if !fn(e) {
+			return false
+		}
+	}
+	if leaf {
+		return true
+	}
+	return bt.ascend(n.children[len(n.children)-1], from, fn)
+}
+
+// descend visits the subtree of n in reverse order calling fn for each element.
+// When from is given elements greater than from are skipped along with the
+// subtrees that can only contain elements greater than from.
+//
+// Returns false when fn stopped the iteration.
+func (bt *btree[K]) descend(n *node[K], from *K, fn func(K) bool) bool {
+	leaf := len(n.children) == 0
+	end := len(n.elements)
+	if from != nil {
+		end = n.searchAfter(*from, bt.cmp)
+	}
+	for i := end - 1; 0 <= i; i-- {
+		e := n.elements[i]
+		if !leaf && !bt.descend(n.children[i+1], from, fn) {
+			return false
+		}
+		// Everything before e is at most e, so there is nothing left to skip.
+		from = nil
+		if !fn(e) {
+			return false
+		}
+	}
+	if leaf {
+		return true
+	}
+	return bt.descend(n.children[0], from, fn)
+}
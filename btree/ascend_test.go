@@ -0,0 +1,130 @@
+package btree
+
+import (
+	"testing"
+)
+
+func TestAllFunc(t *testing.T) {
+	for degree := 3; degree <= 7; degree++ {
+		bt, _ := NewOrdered(degree, 5, 3, 9, 1, 7, 3, 8, 2, 6, 4, 0)
+		got := []int{}
+		bt.AllFunc(func(e int) bool {
+			got = append(got, e)
+			return true
+		})
+		checkSequence(t, got, 0, 1, 2, 3, 3, 4, 5, 6, 7, 8, 9)
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		bt, _ := NewOrdered[int](3)
+		bt.AllFunc(func(e int) bool {
+			t.Errorf("did not expect %v", e)
+			return true
+		})
+	})
+}
+
+func TestAscendFunc(t *testing.T) {
+	bt, _ := NewOrdered(3, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18)
+
+	t.Run("existing", func(t *testing.T) {
+		checkSequence(t, collect(bt.AscendFunc, 12), 12, 14, 16, 18)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		checkSequence(t, collect(bt.AscendFunc, 11), 12, 14, 16, 18)
+	})
+
+	t.Run("past end", func(t *testing.T) {
+		checkSequence(t, collect(bt.AscendFunc, 19))
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		got := []int{}
+		bt.AscendFunc(3, func(e int) bool {
+			got = append(got, e)
+			return len(got) < 3
+		})
+		checkSequence(t, got, 4, 6, 8)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 1, 1, 1, 1, 1, 0, 2)
+		checkSequence(t, collect(bt.AscendFunc, 1), 1, 1, 1, 1, 1, 1, 2)
+	})
+}
+
+func TestDescendFunc(t *testing.T) {
+	bt, _ := NewOrdered(3, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18)
+
+	t.Run("existing", func(t *testing.T) {
+		checkSequence(t, collect(bt.DescendFunc, 6), 6, 4, 2, 0)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		checkSequence(t, collect(bt.DescendFunc, 7), 6, 4, 2, 0)
+	})
+
+	t.Run("before start", func(t *testing.T) {
+		checkSequence(t, collect(bt.DescendFunc, -1))
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		got := []int{}
+		bt.DescendFunc(100, func(e int) bool {
+			got = append(got, e)
+			return len(got) < 2
+		})
+		checkSequence(t, got, 18, 16)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 1, 1, 1, 1, 1, 0, 2)
+		checkSequence(t, collect(bt.DescendFunc, 1), 1, 1, 1, 1, 1, 1, 0)
+	})
+}
+
+func TestRangeFunc(t *testing.T) {
+	values := []int{}
+	for i := 0; i < 100; i++ {
+		values = append(values, i)
+	}
+	bt, _ := NewOrdered(4, values...)
+
+	got := []int{}
+	bt.RangeFunc(10, 15, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	checkSequence(t, got, 10, 11, 12, 13, 14)
+
+	got = []int{}
+	bt.RangeFunc(15, 10, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	checkSequence(t, got)
+}
+
+// collect gathers every element visited by an ascend or descend function.
+func collect(visit func(int, func(int) bool), from int) []int {
+	got := []int{}
+	visit(from, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	return got
+}
+
+// checkSequence asserts got matches exactly the values for want.
+func checkSequence(t *testing.T, got []int, want ...int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	}
+}
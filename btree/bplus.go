@@ -0,0 +1,449 @@
+package btree
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// bplusTree is a B+tree. Unlike btree every element is kept in a leaf. Internal
+// nodes only hold copies of elements, called separators, that guide a search to
+// the right leaf.
+//
+// Leaves are linked to the leaves before and after them, so once a search
+// reaches a leaf the following elements are visited by walking the leaves
+// instead of going back up the tree.
+type bplusTree[K any] struct {
+	// root is the entry node of the tree.
+	root *bplusNode[K]
+	// degree is the maximum amount of elements a node in the tree can contain.
+	// When the maximum is exceeded the node will perform a split operation.
+	degree int
+	// cmp orders the elements of the tree.
+	cmp func(a, b K) int
+	// len is the count of elements in the leaves.
+	len int
+}
+
+// bplusNode makes up a B+tree. A leaf holds elements and is linked to its
+// neighbouring leaves. An internal node holds separators and children.
+type bplusNode[K any] struct {
+	// elements are ordered from least to greatest. In an internal node every
+	// element of the child at i is greater than or equal to the separator at
+	// i-1 and less than or equal to the separator at i.
+	elements []K
+	// A node maintains elements + 1 children at all times. Leaves have none.
+	children []*bplusNode[K]
+	// prev and next link a leaf to the leaves before and after it. They are
+	// nil for internal nodes and at the ends of the tree.
+	prev, next *bplusNode[K]
+}
+
+// NewBPlus returns a B+tree with the given degree where elements are ordered by
+// cmp.
+//
+// The degree and cmp follow the same rules as New.
+//
+// Given values are provided, the tree will be populated with the values. The
+// complexity of inserting these values is O(n log n).
+func NewBPlus[K any](degree int, cmp func(a, b K) int, values ...K) (*bplusTree[K], error) {
+	if err := checkConfig(degree, cmp); err != nil {
+		return nil, err
+	}
+	bt := &bplusTree[K]{
+		degree: degree,
+		cmp:    cmp,
+	}
+	for _, v := range values {
+		bt.Insert(v)
+	}
+	return bt, nil
+}
+
+// NewBPlusOrdered returns a B+tree with the given degree for a naturally
+// ordered type. It behaves the same as NewBPlus with cmp.Compare as the
+// comparator.
+func NewBPlusOrdered[K cmp.Ordered](degree int, values ...K) (*bplusTree[K], error) {
+	return NewBPlus(degree, cmp.Compare[K], values...)
+}
+
+// Len returns the count of elements in the tree.
+//
+// The complexity is O(1).
+func (bt *bplusTree[K]) Len() int {
+	return bt.len
+}
+
+// Exists checks for the existence of the given value.
+//
+// The complexity is O(log n).
+func (bt *bplusTree[K]) Exists(value K) bool {
+	n, i := bt.seek(value)
+	return n != nil && bt.cmp(n.elements[i], value) == 0
+}
+
+// Insert inserts an element into the tree.
+//
+// Given the value already exists in the tree, the value will still be inserted
+// as a duplicate after the existing elements.
+//
+// The complexity is O(log n).
+func (bt *bplusTree[K]) Insert(value K) {
+	bt.len++
+	// No nodes at all so create a root leaf.
+	if bt.root == nil {
+		bt.root = &bplusNode[K]{elements: []K{value}}
+		return
+	}
+	bt.insert(bt.root, value)
+	// Splitting the root is what grows the tree in height.
+	if bt.degree <= len(bt.root.elements) {
+		root := &bplusNode[K]{children: []*bplusNode[K]{bt.root}}
+		bt.split(root, 0)
+		bt.root = root
+	}
+}
+
+// insert recursively follows nodes until hitting a leaf and inserts the value
+// there. Children that exceed the degree are split on the way back up.
+func (bt *bplusTree[K]) insert(n *bplusNode[K], value K) {
+	i := upperBound(n.elements, value, bt.cmp)
+	if len(n.children) == 0 {
+		n.elements = slices.Insert(n.elements, i, value)
+		return
+	}
+	bt.insert(n.children[i], value)
+	bt.split(n, i)
+}
+
+// split splits the child at index i of n when the child exceeds the tree's
+// degree. The child keeps the left half of its elements and a new node to its
+// right takes the rest.
+//
+// A split leaf keeps every element, a copy of the first element of the new
+// leaf becomes the separator in n. A split internal node moves its middle
+// separator into n the same way btree does.
+func (bt *bplusTree[K]) split(n *bplusNode[K], i int) {
+	child := n.children[i]
+	if len(child.elements) < bt.degree {
+		return
+	}
+	right := &bplusNode[K]{}
+	var separator K
+	if len(child.children) == 0 {
+		middleIndex := len(child.elements) / 2
+		right.elements = slices.Clone(child.elements[middleIndex:])
+		child.elements = slices.Clip(child.elements[:middleIndex])
+		separator = right.elements[0]
+		right.prev, right.next = child, child.next
+		if child.next != nil {
+			child.next.prev = right
+		}
+		child.next = right
+	} else {
+		middleIndex := (len(child.elements) - 1) / 2
+		separator = child.elements[middleIndex]
+		right.elements = slices.Clone(child.elements[middleIndex+1:])
+		right.children = slices.Clone(child.children[middleIndex+1:])
+		child.elements = slices.Clip(child.elements[:middleIndex])
+		child.children = slices.Clip(child.children[:middleIndex+1])
+	}
+	n.elements = slices.Insert(n.elements, i, separator)
+	n.children = slices.Insert(n.children, i+1, right)
+}
+
+// Delete removes a single occurrence of the given value from the tree.
+//
+// Returns true when the value existed and was removed.
+//
+// The complexity is O(log n).
+func (bt *bplusTree[K]) Delete(value K) bool {
+	if bt.root == nil || !bt.delete(bt.root, value) {
+		return false
+	}
+	bt.len--
+	// An empty root either empties the tree or is replaced by its only child,
+	// which is what shrinks the tree in height.
+	if len(bt.root.elements) == 0 {
+		if len(bt.root.children) == 0 {
+			bt.root = nil
+		} else {
+			bt.root = bt.root.children[0]
+		}
+	}
+	return true
+}
+
+// delete recursively removes a single occurrence of value from the subtree of
+// n. Children that fall below the minimum amount of elements are rebalanced on
+// the way back up.
+//
+// Returns false when the value is not in the subtree.
+func (bt *bplusTree[K]) delete(n *bplusNode[K], value K) bool {
+	if len(n.children) == 0 {
+		i, found := slices.BinarySearchFunc(n.elements, value, bt.cmp)
+		if found {
+			n.elements = slices.Delete(n.elements, i, i+1)
+		}
+		return found
+	}
+	// Separators equal to value do not say which side of them the value is on,
+	// so every child between them is a candidate.
+	lo, _ := slices.BinarySearchFunc(n.elements, value, bt.cmp)
+	for i := upperBound(n.elements, value, bt.cmp); lo <= i; i-- {
+		if bt.delete(n.children[i], value) {
+			bt.rebalance(n, i)
+			return true
+		}
+	}
+	return false
+}
+
+// rebalance fixes the child at index i of n when it has fallen below the
+// minimum amount of elements. The child first tries to borrow an element from a
+// sibling. When neither sibling can spare an element the child is merged with a
+// sibling, which removes a separator from n.
+func (bt *bplusTree[K]) rebalance(n *bplusNode[K], i int) {
+	if minElements(bt.degree) <= len(n.children[i].elements) {
+		return
+	}
+	if 0 < i && minElements(bt.degree) < len(n.children[i-1].elements) {
+		n.borrowLeft(i)
+		return
+	}
+	if i+1 < len(n.children) && minElements(bt.degree) < len(n.children[i+1].elements) {
+		n.borrowRight(i)
+		return
+	}
+	if 0 < i {
+		n.merge(i - 1)
+	} else {
+		n.merge(i)
+	}
+}
+
+// borrowLeft moves the last element of the child at i-1 into the child at i.
+func (n *bplusNode[K]) borrowLeft(i int) {
+	left, child := n.children[i-1], n.children[i]
+	last := len(left.elements) - 1
+	if len(child.children) == 0 {
+		// Leaves move the element itself and copy it up as the separator.
+		child.elements = slices.Insert(child.elements, 0, left.elements[last])
+		n.elements[i-1] = child.elements[0]
+	} else {
+		// Internal nodes rotate the separator through n.
+		child.elements = slices.Insert(child.elements, 0, n.elements[i-1])
+		n.elements[i-1] = left.elements[last]
+		child.children = slices.Insert(child.children, 0, left.children[last+1])
+		left.children = left.children[:last+1]
+	}
+	left.elements = left.elements[:last]
+}
+
+// borrowRight moves the first element of the child at i+1 into the child at i.
+func (n *bplusNode[K]) borrowRight(i int) {
+	child, right := n.children[i], n.children[i+1]
+	if len(child.children) == 0 {
+		child.elements = append(child.elements, right.elements[0])
+		right.elements = slices.Delete(right.elements, 0, 1)
+		n.elements[i] = right.elements[0]
+	} else {
+		child.elements = append(child.elements, n.elements[i])
+		n.elements[i] = right.elements[0]
+		right.elements = slices.Delete(right.elements, 0, 1)
+		child.children = append(child.children, right.children[0])
+		right.children = slices.Delete(right.children, 0, 1)
+	}
+}
+
+// merge combines the child at i and the child at i+1 into a single child at i
+// and removes the separator between them. This is the reverse of split.
+func (n *bplusNode[K]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	if len(left.children) == 0 {
+		left.elements = append(left.elements, right.elements...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+	} else {
+		left.elements = append(left.elements, n.elements[i])
+		left.elements = append(left.elements, right.elements...)
+		left.children = append(left.children, right.children...)
+	}
+	n.elements = slices.Delete(n.elements, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// AllFunc calls fn for every element in the tree from least to greatest.
+// Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + n).
+func (bt *bplusTree[K]) AllFunc(fn func(K) bool) {
+	if bt.root == nil {
+		return
+	}
+	n := bt.root
+	for len(n.children) != 0 {
+		n = n.children[0]
+	}
+	bt.walk(n, 0, fn)
+}
+
+// AscendFunc calls fn for every element greater than or equal to from, from
+// least to greatest. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *bplusTree[K]) AscendFunc(from K, fn func(K) bool) {
+	n, i := bt.seek(from)
+	bt.walk(n, i, fn)
+}
+
+// DescendFunc calls fn for every element less than or equal to from, from
+// greatest to least. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *bplusTree[K]) DescendFunc(from K, fn func(K) bool) {
+	if bt.root == nil {
+		return
+	}
+	// Every element of the children after the one followed is greater than
+	// from.
+	n := bt.root
+	for len(n.children) != 0 {
+		n = n.children[upperBound(n.elements, from, bt.cmp)]
+	}
+	i := upperBound(n.elements, from, bt.cmp)
+	for {
+		for i--; 0 <= i; i-- {
+			if !fn(n.elements[i]) {
+				return
+			}
+		}
+		if n = n.prev; n == nil {
+			return
+		}
+		i = len(n.elements)
+	}
+}
+
+// RangeFunc calls fn for every element greater than or equal to lo and less
+// than hi, from least to greatest. Iteration stops early when fn returns false.
+//
+// The range is found with a single descent to the leaf holding lo, after which
+// the leaves are walked until hi.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *bplusTree[K]) RangeFunc(lo, hi K, fn func(K) bool) {
+	bt.AscendFunc(lo, func(e K) bool {
+		if 0 <= bt.cmp(e, hi) {
+			return false
+		}
+		return fn(e)
+	})
+}
+
+// seek returns the leaf and index of the first element greater than or equal
+// to value, or a nil leaf when there is no such element.
+func (bt *bplusTree[K]) seek(value K) (*bplusNode[K], int) {
+	if bt.root == nil {
+		return nil, 0
+	}
+	// The children before the one followed only hold elements less than
+	// value.
+	n := bt.root
+	for len(n.children) != 0 {
+		i, _ := slices.BinarySearchFunc(n.elements, value, bt.cmp)
+		n = n.children[i]
+	}
+	i, _ := slices.BinarySearchFunc(n.elements, value, bt.cmp)
+	// The element may be the first element of the next leaf.
+	if i == len(n.elements) {
+		return n.next, 0
+	}
+	return n, i
+}
+
+// walk calls fn for every element starting at index i of the leaf n and
+// continuing through the following leaves. Returns when fn returns false.
+func (bt *bplusTree[K]) walk(n *bplusNode[K], i int, fn func(K) bool) {
+	for ; n != nil; n, i = n.next, 0 {
+		for ; i < len(n.elements); i++ {
+			if !fn(n.elements[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Validate checks the structure of the tree and returns an error describing the
+// first violation found, or nil when the tree is valid.
+//
+// The same rules as btree's Validate are checked, except that the count of
+// elements is checked for the whole tree. Additionally the leaves must be
+// linked in order in both directions.
+//
+// The complexity is O(n).
+func (bt *bplusTree[K]) Validate() error {
+	if bt.root == nil {
+		if bt.len != 0 {
+			return fmt.Errorf("tree is empty, the len is %v", bt.len)
+		}
+		return nil
+	}
+	v := bplusValidator[K]{
+		nodeChecker: newNodeChecker(bt.degree, bt.cmp),
+	}
+	if err := v.validate(bt.root, "root", nil, nil, 0); err != nil {
+		return err
+	}
+	if v.count != bt.len {
+		return fmt.Errorf("tree has %v elements, the len is %v", v.count, bt.len)
+	}
+	if v.prev != nil && v.prev.next != nil {
+		return fmt.Errorf("last leaf has a next leaf")
+	}
+	return nil
+}
+
+// bplusValidator holds the state shared while validating a B+tree.
+type bplusValidator[K any] struct {
+	nodeChecker[K]
+	// count is the amount of elements in the leaves found so far.
+	count int
+	// prev is the last leaf found.
+	prev *bplusNode[K]
+}
+
+// validate recursively checks the subtree of n. lo and hi are the separators
+// surrounding n, nil when there is no separator on that side.
+func (v *bplusValidator[K]) validate(n *bplusNode[K], path string, lo, hi *K, depth int) error {
+	if err := v.check(path, n.elements, len(n.children), lo, hi, depth); err != nil {
+		return err
+	}
+	if len(n.children) == 0 {
+		// Leaves are found in order, so each must be linked to the one found
+		// before it.
+		if n.prev != v.prev {
+			return fmt.Errorf("%v: leaf is not linked to the leaf before it", path)
+		}
+		if v.prev != nil && v.prev.next != n {
+			return fmt.Errorf("%v: leaf before it is not linked to the leaf", path)
+		}
+		v.prev = n
+		v.count += len(n.elements)
+		return nil
+	}
+
+	if n.prev != nil || n.next != nil {
+		return fmt.Errorf("%v: internal node is linked to a leaf", path)
+	}
+	for i, c := range n.children {
+		childLo, childHi := childBounds(n.elements, i, lo, hi)
+		childPath := fmt.Sprintf("%v.children[%v]", path, i)
+		if err := v.validate(c, childPath, childLo, childHi, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestBPlusInsert(t *testing.T) {
+	bt, _ := NewBPlusOrdered(3, 1, 2, 3)
+	// The leaf splits in half and a copy of the first element of the right
+	// leaf becomes the separator.
+	checkBPlusNode(t, bt.root, 2)
+	checkBPlusNode(t, bt.root.children[0], 1)
+	checkBPlusNode(t, bt.root.children[1], 2, 3)
+
+	bt.Insert(4)
+	bt.Insert(5)
+	// Internal nodes split the same way btree nodes do, moving the middle
+	// separator up.
+	checkBPlusNode(t, bt.root, 3)
+	checkBPlusNode(t, bt.root.children[0], 2)
+	checkBPlusNode(t, bt.root.children[1], 4)
+	leaves := [][]int{{1}, {2}, {3}, {4, 5}}
+	n := bt.root.children[0].children[0]
+	for i, want := range leaves {
+		checkBPlusNode(t, n, want...)
+		if i+1 < len(leaves) && n.next.prev != n {
+			t.Errorf("expected leaf %v to be linked both ways", want)
+		}
+		n = n.next
+	}
+	if n != nil {
+		t.Error("expected the last leaf to have no next leaf")
+	}
+}
+
+func TestBPlusOperations(t *testing.T) {
+	for _, degree := range []int{3, 4, 5, 8} {
+		t.Run(fmt.Sprintf("degree %v", degree), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(degree)))
+			bt, _ := NewBPlusOrdered[int](degree)
+			model := []int{}
+			for i := 0; i < 2000; i++ {
+				// Values are drawn from a small range so duplicates span
+				// leaves.
+				v := rng.Intn(50)
+				if rng.Intn(3) == 0 {
+					j, want := slices.BinarySearch(model, v)
+					if want {
+						model = slices.Delete(model, j, j+1)
+					}
+					if got := bt.Delete(v); got != want {
+						t.Fatalf("expected delete %v to be %v got %v", v, want, got)
+					}
+				} else {
+					bt.Insert(v)
+					model = slices.Insert(model, sort.SearchInts(model, v+1), v)
+				}
+				if got, want := bt.Exists(v), slices.Contains(model, v); got != want {
+					t.Fatalf("expected exists %v to be %v got %v", v, want, got)
+				}
+				checkBPlusModel(t, bt, model)
+			}
+			for len(model) != 0 {
+				v := model[rng.Intn(len(model))]
+				j, _ := slices.BinarySearch(model, v)
+				model = slices.Delete(model, j, j+1)
+				if !bt.Delete(v) {
+					t.Fatalf("expected %v to be deleted", v)
+				}
+				checkBPlusModel(t, bt, model)
+			}
+			if bt.root != nil {
+				t.Error("expected the root to be nil once every element is deleted")
+			}
+		})
+	}
+}
+
+func TestBPlusScans(t *testing.T) {
+	empty, _ := NewBPlusOrdered[int](3)
+	checkSequence(t, collectScan(empty.AllFunc))
+	checkSequence(t, collectScan(func(fn func(int) bool) { empty.RangeFunc(0, 10, fn) }))
+	checkSequence(t, collectScan(func(fn func(int) bool) { empty.DescendFunc(10, fn) }))
+
+	bt, _ := NewBPlusOrdered(3, 5, 3, 9, 1, 7, 8, 2, 6, 4, 0, 4, 4)
+
+	t.Run("range", func(t *testing.T) {
+		got := collectScan(func(fn func(int) bool) { bt.RangeFunc(3, 6, fn) })
+		checkSequence(t, got, 3, 4, 4, 4, 5)
+		got = collectScan(func(fn func(int) bool) { bt.RangeFunc(-5, 2, fn) })
+		checkSequence(t, got, 0, 1)
+		got = collectScan(func(fn func(int) bool) { bt.RangeFunc(10, 20, fn) })
+		checkSequence(t, got)
+	})
+
+	t.Run("ascend", func(t *testing.T) {
+		got := collectScan(func(fn func(int) bool) { bt.AscendFunc(8, fn) })
+		checkSequence(t, got, 8, 9)
+	})
+
+	t.Run("descend", func(t *testing.T) {
+		got := collectScan(func(fn func(int) bool) { bt.DescendFunc(4, fn) })
+		checkSequence(t, got, 4, 4, 4, 3, 2, 1, 0)
+		got = collectScan(func(fn func(int) bool) { bt.DescendFunc(-1, fn) })
+		checkSequence(t, got)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		got := []int{}
+		bt.AllFunc(func(e int) bool {
+			got = append(got, e)
+			return len(got) < 3
+		})
+		checkSequence(t, got, 0, 1, 2)
+	})
+}
+
+func TestBPlusValidate(t *testing.T) {
+	newTree := func() *bplusTree[int] {
+		bt, _ := NewBPlusOrdered(3, 1, 2, 3, 4, 5, 6, 7)
+		return bt
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := newTree().Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("broken link", func(t *testing.T) {
+		bt := newTree()
+		leaf := bt.root.children[1].children[0]
+		leaf.prev = nil
+		checkValidateError(t, bt, "root.children[1].children[0]: leaf is not linked to the leaf before it")
+	})
+
+	t.Run("element outside separators", func(t *testing.T) {
+		bt := newTree()
+		bt.root.children[1].children[0].elements[0] = 2
+		checkValidateError(t, bt, "root.children[1].children[0]: element 2 is less than parent element 3")
+	})
+
+	t.Run("len", func(t *testing.T) {
+		bt := newTree()
+		bt.len++
+		checkValidateError(t, bt, "tree has 7 elements, the len is 8")
+	})
+}
+
+func TestNewBPlusDegree(t *testing.T) {
+	if _, err := NewBPlusOrdered[int](2); err == nil {
+		t.Error("expected an error for degree 2")
+	}
+	if _, err := NewBPlus[int](3, nil); err == nil {
+		t.Error("expected an error for a nil comparator")
+	}
+}
+
+func BenchmarkRange(b *testing.B) {
+	const size = 100000
+	values := make([]int, size)
+	for i := range values {
+		values[i] = i
+	}
+	bt, _ := BulkLoadOrdered(64, values, 1)
+	bp, _ := NewBPlusOrdered[int](64)
+	for _, v := range values {
+		bp.Insert(v)
+	}
+	ranges := map[string]func(lo, hi int, fn func(int) bool){
+		"btree": bt.RangeFunc,
+		"bplus": bp.RangeFunc,
+	}
+	for _, name := range []string{"btree", "bplus"} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				lo := (i * 7919) % (size - 1000)
+				ranges[name](lo, lo+1000, func(int) bool { return true })
+			}
+		})
+	}
+}
+
+// checkBPlusModel asserts the tree is valid and holds exactly the sorted
+// model.
+func checkBPlusModel(t *testing.T, bt *bplusTree[int], model []int) {
+	t.Helper()
+	if err := bt.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if bt.Len() != len(model) {
+		t.Fatalf("expected len to be %v got %v", len(model), bt.Len())
+	}
+	if got := collectScan(bt.AllFunc); !slices.Equal(got, model) {
+		t.Fatalf("expected elements to be %v got %v", model, got)
+	}
+}
+
+func checkBPlusNode(t *testing.T, n *bplusNode[int], want ...int) {
+	t.Helper()
+	if !slices.Equal(n.elements, want) {
+		t.Errorf("expected node elements to be %v got %v", want, n.elements)
+	}
+}
+
+func collectScan(scan func(func(int) bool)) []int {
+	got := []int{}
+	scan(func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	return got
+}
@@ -2,53 +2,72 @@
 package btree
 
 // TODO:
-// - Implement Delete
 // - Test coverage
-// - Generic values
 
 import (
+	"cmp"
 	"errors"
+	"slices"
+	"sort"
 )
 
 // btree represents a single btree data structure made up of nodes.
-type btree struct {
+type btree[K any] struct {
 	// root is the entry node of the tree.
-	root *node
+	root *node[K]
 	// degree is the maximum amount of elements a node in the btree can contain.
 	// When the maximum is exceeded the node will perform a split operation.
 	degree int
+	// cmp orders the elements of the tree. It returns a negative number when a
+	// is less than b, a positive number when a is greater than b and zero when
+	// a and b are equal.
+	cmp func(a, b K) int
+	// cow identifies the nodes this tree is allowed to modify in place. Nodes
+	// with a different cow may be shared with a clone and are copied before
+	// they are modified.
+	cow *cow
 }
 
 // node makes up a btree. There are three different kinds of nodes in this tree:
-// Root, a node with no parent; Internal, a node with a parent and children;
-// Leaf, a node with a parent and no children.
-type node struct {
-	// parent is nil when the node is the root of the tree.
-	parent *node
+// Root, the node the tree starts at; Internal, a node below the root with
+// children; Leaf, a node below the root with no children.
+//
+// Nodes do not point to their parent. A node can be shared by several trees
+// after a clone, so it has no single parent to point to.
+type node[K any] struct {
+	// cow is the cow of the tree that created the node.
+	cow *cow
 	// elements are ordered from least to greatest. elements do not exceed the
 	// degree of their associated btree.
-	elements []int
+	elements []K
 	// A node maintains elements + 1 children at all times.
-	children []*node
+	children []*node[K]
+	// count is the amount of elements in the subtree rooted at this node,
+	// including the node's own elements.
+	count int
 }
 
-// New returns a tree with the given degree.
+// New returns a tree with the given degree where elements are ordered by cmp.
 //
 // When a node reaches the given degree of elements the node will split.
 //
-// This implementation allows degrees between 3-7 inclusive.
+// This implementation allows any degree of at least 3. Large degrees, such as
+// the hundreds of elements that fit in a page of memory, are searched with a
+// binary search so lookups stay O(log n).
+//
+// cmp returns a negative number when a is less than b, a positive number when a
+// is greater than b and zero when a and b are equal.
 //
 // Given values are provided, the tree will be populated with the values. The
 // complexity of inserting these values is O(n log n).
-func New(degree int, values ...int) (*btree, error) {
-	if degree < 3 {
-		return nil, errors.New("tree must not have degree less than 3")
+func New[K any](degree int, cmp func(a, b K) int, values ...K) (*btree[K], error) {
+	if err := checkConfig(degree, cmp); err != nil {
+		return nil, err
 	}
-	if 7 < degree {
-		return nil, errors.New("tree must not have degree greater than 7")
-	}
-	nt := &btree{
+	nt := &btree[K]{
 		degree: degree,
+		cmp:    cmp,
+		cow:    &cow{},
 	}
 	for _, v := range values {
 		nt.Insert(v)
@@ -56,31 +75,48 @@ func New(degree int, values ...int) (*btree, error) {
 	return nt, nil
 }
 
+// checkConfig returns an error when a tree cannot be built with the given
+// degree and cmp.
+func checkConfig[K any](degree int, cmp func(a, b K) int) error {
+	if degree < 3 {
+		return errors.New("tree must not have degree less than 3")
+	}
+	if cmp == nil {
+		return errors.New("tree must have a comparator")
+	}
+	return nil
+}
+
+// NewOrdered returns a tree with the given degree for a naturally ordered type.
+// It behaves the same as New with cmp.Compare as the comparator.
+func NewOrdered[K cmp.Ordered](degree int, values ...K) (*btree[K], error) {
+	return New(degree, cmp.Compare[K], values...)
+}
+
 // Exists checks for the existence of the given value.
 //
 // The complexity is O(log n).
-func (bt *btree) Exists(value int) bool {
+func (bt *btree[K]) Exists(value K) bool {
 	if bt.root == nil {
 		return false
 	}
 	return bt.exists(bt.root, value)
 }
 
-func (bt *btree) exists(n *node, value int) bool {
+func (bt *btree[K]) exists(n *node[K], value K) bool {
 	// Check if value is in the current node.
-	for _, e := range n.elements {
-		if e == value {
-			return true
-		}
+	i, found := n.search(value, bt.cmp)
+	if found {
+		return true
 	}
 	// If the node is a leaf the value does not exist.
 	if len(n.children) == 0 {
 		return false
 	}
-	// Existence is still unknown, determine what child node to search next,
-	// then recursively search the next child node.
-	childNode := n.getChildContaining(value)
-	return bt.exists(childNode, value)
+	// Existence is still unknown. The value is greater than every element
+	// before i and less than the element at i, so it can only be in the child
+	// at i.
+	return bt.exists(n.children[i], value)
 }
 
 // Insert inserts an element into the tree.
@@ -89,231 +125,337 @@ func (bt *btree) exists(n *node, value int) bool {
 // as a duplicate.
 //
 // The complexity is O(log n).
-func (bt *btree) Insert(value int) {
+func (bt *btree[K]) Insert(value K) {
 	// No nodes at all so create a root node.
 	if bt.root == nil {
-		bt.root = &node{
-			elements: []int{value},
-		}
+		bt.root = bt.newNode([]K{value}, nil)
 		return
 	}
 	// Root node exists, attempt to insert into the root node. In case the root
 	// node is not a leaf, insert will recursively find a leaf node to insert
 	// into.
+	bt.root = bt.mutable(bt.root)
 	bt.insert(bt.root, value)
+	// The root is split last since every other node is split by its parent.
+	if bt.degree <= len(bt.root.elements) {
+		bt.splitRoot()
+	}
 }
 
 // insert recursively follows nodes until hitting a leaf node. Once a leaf is
 // hit, an insert will be performed (whether or not the insert is allowed based
-// off of the btree's degree). Given the insert leaves the node in an invalid
-// state, splitting is performed to make the tree valid again.
-func (bt *btree) insert(n *node, value int) {
+// off of the btree's degree). Given the insert leaves a node in an invalid
+// state, splitting is performed on the way back up to make the tree valid
+// again.
+//
+// n must be mutable. Every node on the way down is made mutable before it is
+// changed.
+func (bt *btree[K]) insert(n *node[K], value K) {
+	// The value always ends up in the subtree of every node on the way down.
+	n.count++
 	// Insert if leaf.
 	if len(n.children) == 0 {
-		n.addElement(value)
-		// Start splitting if needed.
-		bt.split(n)
-	} else {
-		// Recursively try to insert on the next sub tree.
-		childNode := n.getChildContaining(value)
-		bt.insert(childNode, value)
-	}
-}
-
-// split splits a node exceeding a tree's degree. split continues to recursively
-// process parent nodes until all parent nodes have valid degrees.
-func (bt *btree) split(n *node) {
-	// Done splitting, the current node is a valid degree.
-	if len(n.elements) < bt.degree {
+		n.addElement(value, bt.cmp)
 		return
 	}
+	// Recursively try to insert on the next sub tree.
+	i := n.searchAfter(value, bt.cmp)
+	bt.insert(bt.mutableChild(n, i), value)
+	// Split the child if the insert made it exceed the degree.
+	bt.split(n, i)
+}
 
-	// The current node is the root node and needs to be split.
-	if n.parent == nil {
-		bt.splitRoot()
+// split splits the child at index i of n when the child exceeds the tree's
+// degree. The middle element of the child is inserted into n and the
+// partitions to the left and right of the middle element become children of n
+// where the split child used to be.
+//
+// A split can make n exceed the degree, which is handled by the caller of
+// insert on n.
+func (bt *btree[K]) split(n *node[K], i int) {
+	child := n.children[i]
+	// Done splitting, the child is a valid degree.
+	if len(child.elements) < bt.degree {
 		return
 	}
-
-	// The current node is an internal node that needs splitting.
-	bt.splitInternal(n)
-
-	// Recursively continue to split.
-	bt.split(n.parent)
+	middleElement, leftElements, rightElements := child.getPartitionedElements()
+	leftChildren, rightChildren := child.getPartitionedChildren()
+	n.insertSplit(
+		i,
+		middleElement,
+		bt.newNode(leftElements, leftChildren),
+		bt.newNode(rightElements, rightChildren),
+	)
 }
 
 // splitRoot creates a new root node with the middle element of the root node as
 // the new root node. The left and right partitions become the new root nodes
 // children.
 // This procedure is what grows the tree in height.
-func (bt *btree) splitRoot() {
-	middle, lefts, rights := bt.root.getPartitionedElements()
-	leftChildren, rightChildren := bt.root.getPartitionedChildren()
-	bt.root = &node{
-		elements: []int{middle},
-	}
-	bt.root.children = append(bt.root.children, &node{
-		parent:   bt.root,
-		elements: lefts,
-		children: leftChildren,
-	})
-	bt.root.children = append(bt.root.children, &node{
-		parent:   bt.root,
-		elements: rights,
-		children: rightChildren,
-	})
-	return
+func (bt *btree[K]) splitRoot() {
+	root := bt.newNode(nil, []*node[K]{bt.root})
+	bt.split(root, 0)
+	bt.root = root
 }
 
-// splitInternal takes an internal node and inserts it's middle element into the
-// parent. The partitions to the left and right of the middle element then
-// become children of the parent.
-func (bt *btree) splitInternal(n *node) {
-	middleElement, leftElements, rightElements := n.getPartitionedElements()
-	leftChildren, rightChildren := n.getPartitionedChildren()
-
-	n.removeChildFromParent(leftElements)
-
-	// Insert middle into parent and put lefts and rights as children.
-	for i, element := range n.parent.elements {
-		// End of list, append to the end.
-		if element < middleElement && i+1 == len(n.parent.elements) {
-			n.appendSplitInternal(
-				middleElement,
-				leftElements,
-				leftChildren,
-				rightElements,
-				rightChildren,
-			)
-		}
-		// Gone past by 1 insert in order.
-		if element > middleElement {
-			n.insertSplitInternal(
-				i,
-				middleElement,
-				leftElements,
-				leftChildren,
-				rightElements,
-				rightChildren,
-			)
+// Delete removes a single occurrence of the given value from the tree.
+//
+// Returns true when the value existed and was removed.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Delete(value K) bool {
+	// Checking first means nothing is copied when the value does not exist.
+	if !bt.Exists(value) {
+		return false
+	}
+	bt.root = bt.mutable(bt.root)
+	bt.delete(bt.root, value)
+	// The root is allowed to have any amount of elements as long as it has at
+	// least one. An empty root either empties the tree or is replaced by its
+	// only child, which is what shrinks the tree in height.
+	if len(bt.root.elements) == 0 {
+		if len(bt.root.children) == 0 {
+			bt.root = nil
+		} else {
+			bt.root = bt.root.children[0]
 		}
 	}
+	return true
 }
 
-// addElement adds an element to a leaf node while maintaining ordering of the
-// elements.
-func (n *node) addElement(value int) {
-	for i, e := range n.elements {
-		if value < e {
-			n.elements = append(n.elements[:i+1], n.elements[i:]...)
-			n.elements[i] = value
-			return
-		}
+// delete recursively removes a single occurrence of value from the subtree of
+// n, which must contain the value. On the way back up each child that fell
+// below the minimum amount of elements is rebalanced.
+//
+// n must be mutable. Every node on the way down is made mutable before it is
+// changed.
+func (bt *btree[K]) delete(n *node[K], value K) {
+	n.count--
+	i, found := n.search(value, bt.cmp)
+	if len(n.children) == 0 {
+		n.removeElement(i)
+		return
 	}
-	n.elements = append(n.elements, value)
+	if found {
+		// The value is in an internal node. Replace it with its in order
+		// predecessor, which is always the last element of a leaf, so the
+		// removal itself always happens on a leaf.
+		n.elements[i] = bt.deleteMax(bt.mutableChild(n, i))
+	} else {
+		bt.delete(bt.mutableChild(n, i), value)
+	}
+	bt.rebalance(n, i)
 }
 
-// getChildContaining returns the child node potentially containing the given
-// value.
-func (n *node) getChildContaining(value int) *node {
-	for i, el := range n.elements {
-		if el > value {
-			return n.children[i]
-		}
+// deleteMax removes and returns the greatest element in the subtree of n.
+//
+// n must be mutable.
+func (bt *btree[K]) deleteMax(n *node[K]) K {
+	n.count--
+	if len(n.children) == 0 {
+		last := n.elements[len(n.elements)-1]
+		n.removeElement(len(n.elements) - 1)
+		return last
+	}
+	i := len(n.children) - 1
+	last := bt.deleteMax(bt.mutableChild(n, i))
+	bt.rebalance(n, i)
+	return last
+}
+
+// find returns the node and element index of the given value or a nil node
+// when the value does not exist.
+func (bt *btree[K]) find(n *node[K], value K) (*node[K], int) {
+	i, found := n.search(value, bt.cmp)
+	if found {
+		return n, i
 	}
-	return n.children[len(n.children)-1]
+	if len(n.children) == 0 {
+		return nil, 0
+	}
+	return bt.find(n.children[i], value)
+}
+
+// minElements is the least amount of elements a node other than the root of a
+// tree with the given degree can contain. It is the smallest partition split
+// can produce.
+func minElements(degree int) int {
+	return (degree - 1) / 2
+}
+
+// rebalance fixes the child at index i of n when it has fallen below the
+// minimum amount of elements. The child first tries to borrow an element from a
+// sibling through n. When neither sibling can spare an element the child is
+// merged with a sibling, which takes an element from n, so n may in turn need
+// rebalancing by its own parent.
+//
+// n and the child at i must be mutable. A sibling is made mutable before it is
+// changed.
+func (bt *btree[K]) rebalance(n *node[K], i int) {
+	// Done rebalancing, the child is valid.
+	if minElements(bt.degree) <= len(n.children[i].elements) {
+		return
+	}
+	if 0 < i && minElements(bt.degree) < len(n.children[i-1].elements) {
+		bt.mutableChild(n, i-1)
+		n.borrowLeft(i)
+		return
+	}
+	if i+1 < len(n.children) && minElements(bt.degree) < len(n.children[i+1].elements) {
+		bt.mutableChild(n, i+1)
+		n.borrowRight(i)
+		return
+	}
+	if 0 < i {
+		bt.mutableChild(n, i-1)
+		n.merge(i - 1)
+	} else {
+		n.merge(i)
+	}
+}
+
+// addElement adds an element to a leaf node while maintaining ordering of the
+// elements. A duplicate is added after the elements it is equal to.
+func (n *node[K]) addElement(value K, cmp func(a, b K) int) {
+	i := n.searchAfter(value, cmp)
+	if i == len(n.elements) {
+		n.elements = append(n.elements, value)
+		return
+	}
+	n.elements = append(n.elements[:i+1], n.elements[i:]...)
+	n.elements[i] = value
+}
+
+// search returns the index of the first element greater than or equal to value
+// and whether that element is equal to value.
+//
+// The complexity is O(log degree).
+func (n *node[K]) search(value K, cmp func(a, b K) int) (int, bool) {
+	return slices.BinarySearchFunc(n.elements, value, cmp)
+}
+
+// searchAfter returns the index of the first element greater than value.
+//
+// The complexity is O(log degree).
+func (n *node[K]) searchAfter(value K, cmp func(a, b K) int) int {
+	return upperBound(n.elements, value, cmp)
+}
+
+// upperBound returns the index of the first of the ordered elements greater
+// than value.
+//
+// The complexity is O(log n).
+func upperBound[K any](elements []K, value K, cmp func(a, b K) int) int {
+	return sort.Search(len(elements), func(i int) bool {
+		return 0 < cmp(elements[i], value)
+	})
 }
 
 // getPartitionedElements splits and returns the middle, left, and right
 // elements of the given node.
-func (n *node) getPartitionedElements() (int, []int, []int) {
+func (n *node[K]) getPartitionedElements() (K, []K, []K) {
 	middleIndex := (len(n.elements) - 1) / 2
 	middle := n.elements[middleIndex]
-	lefts := n.elements[:middleIndex]
-	rights := n.elements[middleIndex+1:]
+	// The partitions are capped so appending to the left partition can never
+	// overwrite the right partition.
+	lefts := n.elements[:middleIndex:middleIndex]
+	rights := n.elements[middleIndex+1 : len(n.elements) : len(n.elements)]
 	return middle, lefts, rights
 }
 
 // getPartitionedChildren splits and returns the children of the given node
 // into left and right partitions.
-func (n *node) getPartitionedChildren() ([]*node, []*node) {
+func (n *node[K]) getPartitionedChildren() ([]*node[K], []*node[K]) {
 	middleIndex := (len(n.elements) - 1) / 2
-	lefts := []*node{}
-	rights := []*node{}
+	lefts := []*node[K]{}
+	rights := []*node[K]{}
 	if len(n.children) != 0 {
-		lefts = n.children[:middleIndex+1]
-		rights = n.children[middleIndex+1:]
+		lefts = n.children[: middleIndex+1 : middleIndex+1]
+		rights = n.children[middleIndex+1 : len(n.children) : len(n.children)]
 	}
 	return lefts, rights
 }
 
-// removeChildFromParent removes the relation between the node and it's parent.
-func (n *node) removeChildFromParent(leftElements []int) {
-	for i, element := range n.parent.elements {
-		// End of list, remove last child.
-		if i+1 == len(n.parent.elements) {
-			n.parent.children = n.parent.children[:len(n.parent.children)-1]
-			break
-		}
-		// Gone past by 1.
-		if element > leftElements[0] {
-			n.parent.children = append(n.parent.children[:i], n.parent.children[i+1:]...)
-			break
-		}
-	}
+// insertSplit inserts the middle element of a split child into the node along
+// with the nodes made from the left and right partitions. i is the index the
+// split child had in the node's children.
+func (n *node[K]) insertSplit(i int, middleElement K, left, right *node[K]) {
+	n.elements = slices.Insert(n.elements, i, middleElement)
+	n.children[i] = left
+	n.children = slices.Insert(n.children, i+1, right)
 }
 
-// appendSplitInternal appends a split internal node to the end of the nodes
-// parent by appending the split partitions to the nodes parent.
-func (n *node) appendSplitInternal(
-	middleElement int,
-	leftElements []int,
-	leftChildren []*node,
-	rightElements []int,
-	rightChildren []*node,
-) {
-	n.parent.elements = append(n.parent.elements, middleElement)
-	newLeft := &node{
-		parent:   n.parent,
-		elements: leftElements,
-		children: leftChildren,
+// newNode creates a node owned by the tree with the given elements and
+// children.
+func (bt *btree[K]) newNode(elements []K, children []*node[K]) *node[K] {
+	n := &node[K]{
+		cow:      bt.cow,
+		elements: elements,
+		children: children,
+		count:    len(elements),
 	}
-	n.parent.children = append(n.parent.children, newLeft)
-	newRight := &node{
-		parent:   n.parent,
-		elements: rightElements,
-		children: rightChildren,
+	for _, c := range children {
+		n.count += c.count
 	}
-	n.parent.children = append(n.parent.children, newRight)
+	return n
 }
 
-// insertSplitInternal inserts a split internal node made up of middle,
-// lefts, and rights in order with the parent node elements and children.
-func (n *node) insertSplitInternal(
-	i int,
-	middleElement int,
-	leftElements []int,
-	leftChildren []*node,
-	rightElements []int,
-	rightChildren []*node,
-) {
-	leftParentElements := n.parent.elements[:i-1]
-	leftParentElements = append(leftParentElements, middleElement)
-	rightParentElements := n.parent.elements[i:]
-	n.parent.elements = append(leftParentElements, rightParentElements...)
-
-	leftParentChildren := n.parent.children[:i-1]
-	newLeft := &node{
-		parent:   n.parent,
-		elements: leftElements,
-		children: leftChildren,
+// removeElement removes the element at index i from the node.
+func (n *node[K]) removeElement(i int) {
+	n.elements = append(n.elements[:i], n.elements[i+1:]...)
+}
+
+// borrowLeft rotates an element from the child at i-1 through the node into
+// the child at i. The node and both children must be mutable.
+func (n *node[K]) borrowLeft(i int) {
+	left, child := n.children[i-1], n.children[i]
+
+	child.elements = append([]K{n.elements[i-1]}, child.elements...)
+	n.elements[i-1] = left.elements[len(left.elements)-1]
+	left.elements = left.elements[:len(left.elements)-1]
+	left.count--
+	child.count++
+
+	if len(left.children) != 0 {
+		moved := left.children[len(left.children)-1]
+		left.children = left.children[:len(left.children)-1]
+		child.children = append([]*node[K]{moved}, child.children...)
+		left.count -= moved.count
+		child.count += moved.count
 	}
-	leftParentChildren = append(leftParentChildren, newLeft)
-	newRight := &node{
-		parent:   n.parent,
-		elements: rightElements,
-		children: rightChildren,
+}
+
+// borrowRight rotates an element from the child at i+1 through the node into
+// the child at i. The node and both children must be mutable.
+func (n *node[K]) borrowRight(i int) {
+	child, right := n.children[i], n.children[i+1]
+
+	child.elements = append(child.elements, n.elements[i])
+	n.elements[i] = right.elements[0]
+	right.elements = right.elements[1:]
+	right.count--
+	child.count++
+
+	if len(right.children) != 0 {
+		moved := right.children[0]
+		right.children = right.children[1:]
+		child.children = append(child.children, moved)
+		right.count -= moved.count
+		child.count += moved.count
 	}
-	leftParentChildren = append(leftParentChildren, newRight)
-	rightParentChildren := n.parent.children[i:]
-	n.parent.children = append(leftParentChildren, rightParentChildren...)
+}
+
+// merge combines the child at i, the element at i and the child at i+1 into a
+// single child at i. This is the reverse of split. The node and the child at i
+// must be mutable.
+func (n *node[K]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	left.elements = append(left.elements, n.elements[i])
+	left.elements = append(left.elements, right.elements...)
+	left.children = append(left.children, right.children...)
+	left.count += 1 + right.count
+
+	n.removeElement(i)
+	n.children = append(n.children[:i+1], n.children[i+2:]...)
 }
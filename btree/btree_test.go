@@ -1,9 +1,12 @@
 package btree
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestInsertDegree3(t *testing.T) {
-	bt, _ := New(3)
+	bt, _ := NewOrdered[int](3)
 
 	t.Run("insert 1", func(t *testing.T) {
 		bt.Insert(1)
@@ -87,7 +90,7 @@ func TestInsertDegree3(t *testing.T) {
 }
 
 func TestInsertDegree4(t *testing.T) {
-	bt, _ := New(4)
+	bt, _ := NewOrdered[int](4)
 
 	t.Run("insert 1", func(t *testing.T) {
 		bt.Insert(1)
@@ -220,7 +223,7 @@ func TestInsertDegree4(t *testing.T) {
 }
 
 func TestInsertDegree5(t *testing.T) {
-	bt, _ := New(5)
+	bt, _ := NewOrdered[int](5)
 
 	t.Run("insert 1", func(t *testing.T) {
 		bt.Insert(1)
@@ -433,8 +436,43 @@ func TestInsertDegree5(t *testing.T) {
 	})
 }
 
+func TestNewDegree(t *testing.T) {
+	if _, err := NewOrdered[int](2); err == nil {
+		t.Error("expected an error for degree 2")
+	}
+	for _, degree := range []int{3, 8, 64, 256, 4096} {
+		if _, err := NewOrdered[int](degree); err != nil {
+			t.Errorf("expected degree %v to be allowed got %v", degree, err)
+		}
+	}
+}
+
+func TestLargeDegree(t *testing.T) {
+	values := []int{}
+	for i := 0; i < 5000; i++ {
+		values = append(values, (i*7919)%5000)
+	}
+	for _, degree := range []int{64, 256} {
+		bt, _ := NewOrdered(degree, values...)
+		bt.checkValid(t, len(values))
+		for i := 0; i < 5000; i++ {
+			if !bt.Exists(i) {
+				t.Fatalf("degree %v expected %v to exist", degree, i)
+			}
+		}
+		for i, v := range values {
+			if !bt.Delete(v) {
+				t.Fatalf("degree %v expected %v to be deleted", degree, v)
+			}
+			if i%500 == 0 {
+				bt.checkValid(t, len(values)-i-1)
+			}
+		}
+	}
+}
+
 func TestInsertDuplicates(t *testing.T) {
-	bt, _ := New(3)
+	bt, _ := NewOrdered[int](3)
 	bt.Insert(1)
 	bt.Insert(1)
 	bt.Insert(1)
@@ -445,7 +483,7 @@ func TestInsertDuplicates(t *testing.T) {
 }
 
 func TestInsertOrder(t *testing.T) {
-	bt, _ := New(7)
+	bt, _ := NewOrdered[int](7)
 	bt.Insert(6)
 	bt.Insert(4)
 	bt.Insert(2)
@@ -458,7 +496,7 @@ func TestInsertOrder(t *testing.T) {
 
 func TestExists(t *testing.T) {
 	degree := 3
-	bt, _ := New(degree, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13)
+	bt, _ := NewOrdered(degree, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13)
 
 	t.Run("exists", func(t *testing.T) {
 		if !bt.Exists(5) {
@@ -479,7 +517,7 @@ func TestExists(t *testing.T) {
 	})
 
 	t.Run("empty tree", func(t *testing.T) {
-		et, _ := New(3)
+		et, _ := NewOrdered[int](3)
 		if et.Exists(1) {
 			t.Errorf("expected 1 to not exist because the tree is empty")
 		}
@@ -488,7 +526,7 @@ func TestExists(t *testing.T) {
 
 // checkElements asserts a node's elements match exactly the values for elements.
 // order does matter.
-func (n *node) checkElements(t *testing.T, elements ...int) {
+func (n *node[K]) checkElements(t *testing.T, elements ...K) {
 	if len(n.elements) != len(elements) {
 		t.Errorf(
 			"Got node with %v elements, but want node with %v elements",
@@ -497,13 +535,13 @@ func (n *node) checkElements(t *testing.T, elements ...int) {
 		)
 	}
 	for i, e := range elements {
-		if n.elements[i] != e {
+		if any(n.elements[i]) != any(e) {
 			t.Errorf("Invalid match %v with %v", n.elements[i], e)
 		}
 	}
 }
 
-func (n *node) checkChildrenLength(t *testing.T, expectedLength int) {
+func (n *node[K]) checkChildrenLength(t *testing.T, expectedLength int) {
 	if len(n.children) != expectedLength {
 		t.Errorf(
 			"Got %v children, but expected %v children",
@@ -512,3 +550,223 @@ func (n *node) checkChildrenLength(t *testing.T, expectedLength int) {
 		)
 	}
 }
+
+func TestInsertDescending(t *testing.T) {
+	bt, _ := NewOrdered[int](3)
+	for i := 7; 0 < i; i-- {
+		bt.Insert(i)
+	}
+
+	// top level
+	bt.root.checkElements(t, 4)
+	bt.root.checkChildrenLength(t, 2)
+
+	// second level left to right
+	bt.root.children[0].checkElements(t, 2)
+	bt.root.children[0].checkChildrenLength(t, 2)
+	bt.root.children[1].checkElements(t, 6)
+	bt.root.children[1].checkChildrenLength(t, 2)
+
+	// third level left to right
+	bt.root.children[0].children[0].checkElements(t, 1)
+	bt.root.children[0].children[1].checkElements(t, 3)
+	bt.root.children[1].children[0].checkElements(t, 5)
+	bt.root.children[1].children[1].checkElements(t, 7)
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("leaf", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 3, 4)
+		if !bt.Delete(4) {
+			t.Fatal("expected 4 to be deleted")
+		}
+		bt.root.checkElements(t, 2)
+		bt.root.children[0].checkElements(t, 1)
+		bt.root.children[1].checkElements(t, 3)
+	})
+
+	t.Run("internal", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 3, 4)
+		bt.Delete(2)
+		bt.root.checkElements(t, 3)
+		bt.root.children[0].checkElements(t, 1)
+		bt.root.children[1].checkElements(t, 4)
+	})
+
+	t.Run("borrow left", func(t *testing.T) {
+		bt, _ := NewOrdered(4, 1, 2, 3, 4, 0)
+		bt.Delete(3)
+		bt.Delete(4)
+		bt.root.checkElements(t, 1)
+		bt.root.children[0].checkElements(t, 0)
+		bt.root.children[1].checkElements(t, 2)
+	})
+
+	t.Run("borrow right", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 3, 4)
+		bt.Delete(1)
+		bt.root.checkElements(t, 3)
+		bt.root.children[0].checkElements(t, 2)
+		bt.root.children[1].checkElements(t, 4)
+	})
+
+	t.Run("merge and shrink", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 3, 4, 5, 6, 7)
+		bt.Delete(1)
+		bt.root.checkElements(t, 4, 6)
+		bt.root.checkChildrenLength(t, 3)
+		bt.root.children[0].checkElements(t, 2, 3)
+		bt.root.children[1].checkElements(t, 5)
+		bt.root.children[2].checkElements(t, 7)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 1, 1)
+		bt.Delete(1)
+		if !bt.Exists(1) {
+			t.Error("expected a duplicate 1 to still exist")
+		}
+		bt.root.checkElements(t, 1, 1)
+	})
+
+	t.Run("not exists", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 3)
+		if bt.Delete(4) {
+			t.Error("did not expect 4 to be deleted")
+		}
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		bt, _ := NewOrdered[int](3)
+		if bt.Delete(1) {
+			t.Error("did not expect 1 to be deleted")
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		for degree := 3; degree <= 7; degree++ {
+			values := []int{}
+			for i := 0; i < 200; i++ {
+				values = append(values, (i*37)%101)
+			}
+			bt, _ := NewOrdered(degree, values...)
+			for i, v := range values {
+				if !bt.Delete(v) {
+					t.Fatalf("degree %v expected %v to be deleted", degree, v)
+				}
+				bt.checkValid(t, len(values)-i-1)
+			}
+			if bt.root != nil {
+				t.Errorf("degree %v expected root to be nil", degree)
+			}
+		}
+	})
+}
+
+// checkValid asserts the tree is structurally valid and holds count elements.
+func (bt *btree[K]) checkValid(t *testing.T, count int) {
+	t.Helper()
+	if err := bt.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	visited := 0
+	bt.AllFunc(func(K) bool {
+		visited++
+		return true
+	})
+	if visited != count {
+		t.Fatalf("got %v elements want %v", visited, count)
+	}
+	if bt.Len() != count {
+		t.Fatalf("got len %v want %v", bt.Len(), count)
+	}
+}
+
+var benchmarkDegrees = []int{8, 64, 256}
+
+func TestComparator(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		bt, _ := NewOrdered(3, "c", "a", "b", "d")
+		bt.root.checkElements(t, "b")
+		bt.root.children[0].checkElements(t, "a")
+		bt.root.children[1].checkElements(t, "c", "d")
+		if !bt.Exists("d") {
+			t.Error("expected d to exist")
+		}
+	})
+
+	t.Run("reversed", func(t *testing.T) {
+		reverse := func(a, b int) int { return b - a }
+		bt, _ := New(3, reverse, 1, 2, 3, 4)
+		bt.root.checkElements(t, 2)
+		bt.root.children[0].checkElements(t, 4, 3)
+		bt.root.children[1].checkElements(t, 1)
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		type point struct{ x, y int }
+		byXThenY := func(a, b point) int {
+			if a.x != b.x {
+				return a.x - b.x
+			}
+			return a.y - b.y
+		}
+		bt, _ := New(3, byXThenY, point{1, 2}, point{1, 1}, point{0, 5})
+		bt.root.checkElements(t, point{1, 1})
+		bt.root.children[0].checkElements(t, point{0, 5})
+		bt.root.children[1].checkElements(t, point{1, 2})
+		if !bt.Delete(point{0, 5}) {
+			t.Error("expected {0, 5} to be deleted")
+		}
+		if bt.Exists(point{0, 5}) {
+			t.Error("did not expect {0, 5} to exist")
+		}
+	})
+
+	t.Run("nil comparator", func(t *testing.T) {
+		if _, err := New[int](3, nil); err == nil {
+			t.Error("expected an error for a nil comparator")
+		}
+	})
+}
+
+func BenchmarkInsert(b *testing.B) {
+	for _, degree := range benchmarkDegrees {
+		b.Run(fmt.Sprintf("degree %v", degree), func(b *testing.B) {
+			bt, _ := NewOrdered[int](degree)
+			for i := 0; i < b.N; i++ {
+				bt.Insert((i * 7919) % 1000003)
+			}
+		})
+	}
+}
+
+func BenchmarkExists(b *testing.B) {
+	for _, degree := range benchmarkDegrees {
+		b.Run(fmt.Sprintf("degree %v", degree), func(b *testing.B) {
+			bt, _ := NewOrdered[int](degree)
+			for i := 0; i < 100000; i++ {
+				bt.Insert(i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bt.Exists(i % 100000)
+			}
+		})
+	}
+}
+
+func BenchmarkDelete(b *testing.B) {
+	for _, degree := range benchmarkDegrees {
+		b.Run(fmt.Sprintf("degree %v", degree), func(b *testing.B) {
+			bt, _ := NewOrdered[int](degree)
+			for i := 0; i < b.N; i++ {
+				bt.Insert(i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bt.Delete(i)
+			}
+		})
+	}
+}
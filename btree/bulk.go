@@ -0,0 +1,109 @@
+package btree
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// BulkLoad returns a tree with the given degree built from values that are
+// already sorted by cmp.
+//
+// Instead of inserting values one at a time the tree is built bottom up. Leaves
+// are packed first, then each level of internal nodes is built from the level
+// below it until a single root remains.
+//
+// fill is the fraction of a node's capacity that is used while packing. A fill
+// of 1 packs nodes completely which makes the smallest tree, while a lower fill
+// leaves room for inserts before nodes split. fill must be greater than 0 and
+// at most 1. Nodes are never packed below the minimum amount of elements a node
+// can hold.
+//
+// Returns an error when values are not sorted.
+//
+// The complexity is O(n).
+func BulkLoad[K any](
+	degree int,
+	cmp func(a, b K) int,
+	values []K,
+	fill float64,
+) (*btree[K], error) {
+	bt, err := New(degree, cmp)
+	if err != nil {
+		return nil, err
+	}
+	if !(0 < fill && fill <= 1) {
+		return nil, errors.New("fill must be greater than 0 and at most 1")
+	}
+	for i := 1; i < len(values); i++ {
+		if 0 < cmp(values[i-1], values[i]) {
+			return nil, errors.New("values must be sorted")
+		}
+	}
+	if len(values) == 0 {
+		return bt, nil
+	}
+
+	// per is how many elements a packed node aims to hold.
+	per := int(math.Ceil(fill * float64(degree-1)))
+	per = max(per, minElements(bt.degree), 1)
+
+	nodes, separators := bt.buildLevel(values, nil, per)
+	for len(nodes) != 1 {
+		nodes, separators = bt.buildLevel(separators, nodes, per)
+	}
+	bt.root = nodes[0]
+	return bt, nil
+}
+
+// BulkLoadOrdered returns a tree with the given degree for a naturally ordered
+// type built from sorted values. It behaves the same as BulkLoad with
+// cmp.Compare as the comparator.
+func BulkLoadOrdered[K cmp.Ordered](degree int, values []K, fill float64) (*btree[K], error) {
+	return BulkLoad(degree, cmp.Compare[K], values, fill)
+}
+
+// buildLevel packs elements into a level of nodes. children are nil when
+// building leaves, otherwise they are the nodes of the level below and there is
+// one more child than there are elements.
+//
+// A single element between each pair of nodes is not packed, it is instead
+// returned as a separator for the level above.
+func (bt *btree[K]) buildLevel(
+	elements []K,
+	children []*node[K],
+	per int,
+) ([]*node[K], []K) {
+	// Each node holds per elements and is followed by a separator, except for
+	// the last node. The amount of nodes is capped so nodes never fall below
+	// the minimum amount of elements.
+	count := (len(elements) + 1 + per) / (per + 1)
+	count = min(count, (len(elements)+1)/(minElements(bt.degree)+1))
+	count = max(count, 1)
+
+	// Spread the elements evenly so the last node is not left nearly empty.
+	packed := len(elements) - (count - 1)
+	size, extra := packed/count, packed%count
+
+	nodes := make([]*node[K], 0, count)
+	separators := make([]K, 0, count-1)
+	for i := 0; i < count; i++ {
+		n := size
+		if i < extra {
+			n++
+		}
+		var nodeChildren []*node[K]
+		if children != nil {
+			nodeChildren = slices.Clone(children[:n+1])
+			children = children[n+1:]
+		}
+		nodes = append(nodes, bt.newNode(slices.Clone(elements[:n]), nodeChildren))
+		elements = elements[n:]
+		if i+1 < count {
+			separators = append(separators, elements[0])
+			elements = elements[1:]
+		}
+	}
+	return nodes, separators
+}
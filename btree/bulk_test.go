@@ -0,0 +1,92 @@
+package btree
+
+import (
+	"testing"
+)
+
+func TestBulkLoad(t *testing.T) {
+	for _, degree := range []int{3, 4, 5, 6, 7, 64} {
+		for _, fill := range []float64{0.1, 0.5, 0.75, 1} {
+			for n := 0; n < 300; n++ {
+				if 30 < n && n%7 != 0 {
+					continue
+				}
+				values := []int{}
+				for i := 0; i < n; i++ {
+					values = append(values, i/2)
+				}
+				bt, err := BulkLoadOrdered(degree, values, fill)
+				if err != nil {
+					t.Fatalf("degree %v fill %v got error %v", degree, fill, err)
+				}
+				bt.checkValid(t, n)
+				for _, v := range values {
+					if !bt.Exists(v) {
+						t.Fatalf("degree %v fill %v expected %v to exist", degree, fill, v)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("packed", func(t *testing.T) {
+		values := []int{}
+		for i := 0; i < 24; i++ {
+			values = append(values, i)
+		}
+		bt, _ := BulkLoadOrdered(5, values, 1)
+		bt.root.checkElements(t, 4, 9, 14, 19)
+		bt.root.checkChildrenLength(t, 5)
+		bt.root.children[0].checkElements(t, 0, 1, 2, 3)
+		bt.root.children[4].checkElements(t, 20, 21, 22, 23)
+	})
+
+	t.Run("packed small", func(t *testing.T) {
+		bt, _ := BulkLoadOrdered(3, []int{0, 1, 2}, 1)
+		bt.root.checkElements(t, 1)
+		bt.root.children[0].checkElements(t, 0)
+		bt.root.children[1].checkElements(t, 2)
+	})
+
+	t.Run("half full", func(t *testing.T) {
+		values := []int{}
+		for i := 0; i < 8; i++ {
+			values = append(values, i)
+		}
+		bt, _ := BulkLoadOrdered(5, values, 0.5)
+		bt.root.checkElements(t, 2, 5)
+		bt.root.children[0].checkElements(t, 0, 1)
+		bt.root.children[1].checkElements(t, 3, 4)
+		bt.root.children[2].checkElements(t, 6, 7)
+	})
+
+	t.Run("modify after load", func(t *testing.T) {
+		values := []int{}
+		for i := 0; i < 100; i += 2 {
+			values = append(values, i)
+		}
+		bt, _ := BulkLoadOrdered(4, values, 1)
+		for i := 1; i < 100; i += 2 {
+			bt.Insert(i)
+		}
+		bt.checkValid(t, 100)
+		for i := 0; i < 100; i += 3 {
+			bt.Delete(i)
+		}
+		bt.checkValid(t, 66)
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		if _, err := BulkLoadOrdered(3, []int{1, 3, 2}, 1); err == nil {
+			t.Error("expected an error for unsorted values")
+		}
+	})
+
+	t.Run("invalid fill", func(t *testing.T) {
+		for _, fill := range []float64{0, -1, 1.5} {
+			if _, err := BulkLoadOrdered(3, []int{1}, fill); err == nil {
+				t.Errorf("expected an error for fill %v", fill)
+			}
+		}
+	})
+}
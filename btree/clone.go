@@ -0,0 +1,49 @@
+package btree
+
+import (
+	"slices"
+)
+
+// cow identifies the nodes a tree may modify in place. Each tree has its own
+// cow and every node remembers the cow of the tree that created it.
+type cow struct {
+	// _ gives cow a size so every allocated cow has a distinct address.
+	_ byte
+}
+
+// Clone returns an independent copy of the tree. Changes to either tree are not
+// seen by the other.
+//
+// The trees share their nodes after cloning. A node is only copied when one of
+// the trees modifies it, so an insert or delete copies just the path from the
+// root to the nodes it changes.
+//
+// The complexity is O(1).
+func (bt *btree[K]) Clone() *btree[K] {
+	clone := *bt
+	// Both trees get a new cow so neither owns the shared nodes anymore.
+	bt.cow = &cow{}
+	clone.cow = &cow{}
+	return &clone
+}
+
+// mutable returns n when the tree owns it, otherwise a copy of n owned by the
+// tree. The copy does not share elements or children with n.
+func (bt *btree[K]) mutable(n *node[K]) *node[K] {
+	if n.cow == bt.cow {
+		return n
+	}
+	return &node[K]{
+		cow:      bt.cow,
+		elements: slices.Clone(n.elements),
+		children: slices.Clone(n.children),
+		count:    n.count,
+	}
+}
+
+// mutableChild makes the child at index i of n mutable and returns it. n must
+// be mutable.
+func (bt *btree[K]) mutableChild(n *node[K], i int) *node[K] {
+	n.children[i] = bt.mutable(n.children[i])
+	return n.children[i]
+}
@@ -0,0 +1,107 @@
+package btree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	bt, _ := NewOrdered[int](4)
+	model := []int{}
+	for i := 0; i < 100; i++ {
+		bt.Insert(i)
+		model = append(model, i)
+	}
+	clone := bt.Clone()
+
+	for i := 0; i < 100; i += 2 {
+		clone.Delete(i)
+	}
+	for i := 100; i < 150; i++ {
+		bt.Insert(i)
+	}
+	cloneModel := []int{}
+	for i := 1; i < 100; i += 2 {
+		cloneModel = append(cloneModel, i)
+	}
+	for i := 100; i < 150; i++ {
+		model = append(model, i)
+	}
+	checkModel(t, bt, model)
+	checkModel(t, clone, cloneModel)
+}
+
+func TestCloneSharesNodes(t *testing.T) {
+	// The tree is shaped as:
+	//
+	//	        4
+	//	    2       6
+	//	  1   3   5   7
+	bt, _ := NewOrdered(3, 1, 2, 3, 4, 5, 6, 7)
+	clone := bt.Clone()
+	if clone.root != bt.root {
+		t.Fatal("expected clone to share the root")
+	}
+
+	clone.Insert(8)
+	if clone.root == bt.root {
+		t.Error("expected insert to copy the root")
+	}
+	if clone.root.children[0] != bt.root.children[0] {
+		t.Error("expected insert to share the untouched left subtree")
+	}
+	if clone.root.children[1].children[0] != bt.root.children[1].children[0] {
+		t.Error("expected insert to share the untouched leaf")
+	}
+	checkModel(t, bt, []int{1, 2, 3, 4, 5, 6, 7})
+	checkModel(t, clone, []int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	// Once copied the path belongs to the clone and is changed in place.
+	root := clone.root
+	clone.Insert(9)
+	if clone.root != root {
+		t.Error("expected insert to reuse the copied root")
+	}
+}
+
+func TestCloneSnapshots(t *testing.T) {
+	bt, _ := NewOrdered[int](3)
+	model := []int{}
+	snapshots := []*btree[int]{}
+	models := [][]int{}
+	for i := 0; i < 200; i++ {
+		// Mix inserts and deletes so snapshots are taken at every shape of
+		// split, borrow and merge.
+		v := (i * 37) % 50
+		if i%3 == 2 {
+			if j, ok := slices.BinarySearch(model, v); ok {
+				model = slices.Delete(model, j, j+1)
+			}
+			bt.Delete(v)
+		} else {
+			j, _ := slices.BinarySearch(model, v)
+			model = slices.Insert(model, j, v)
+			bt.Insert(v)
+		}
+		if i%10 == 0 {
+			snapshots = append(snapshots, bt.Clone())
+			models = append(models, slices.Clone(model))
+		}
+	}
+	checkModel(t, bt, model)
+	for i, s := range snapshots {
+		checkModel(t, s, models[i])
+	}
+}
+
+func BenchmarkClone(b *testing.B) {
+	bt, _ := NewOrdered[int](64)
+	for i := 0; i < 100000; i++ {
+		bt.Insert(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clone := bt.Clone()
+		clone.Insert(i)
+	}
+}
@@ -0,0 +1,286 @@
+package btree
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// concurrentBtree is a btree that is safe for concurrent use by multiple
+// goroutines.
+//
+// Every node has its own read write latch. Operations latch their way down the
+// tree from the root, which is known as latch crabbing. A reader latches a
+// child before releasing its parent, so readers only ever hold a couple of
+// latches and never block each other. A writer keeps its parent latched only
+// while the child is full, because an insert into a full child splits it and
+// changes the parent. Once a child is known not to split every latch above it
+// is released.
+type concurrentBtree[K any] struct {
+	// latch protects root. A writer holds it for as long as the root may split,
+	// which replaces the root.
+	latch sync.RWMutex
+	// root is the entry node of the tree.
+	root *latchNode[K]
+	// degree is the maximum amount of elements a node in the btree can contain.
+	// When the maximum is exceeded the node will perform a split operation.
+	degree int
+	// cmp orders the elements of the tree.
+	cmp func(a, b K) int
+	// len is the count of elements in the tree.
+	len atomic.Int64
+}
+
+// latchNode makes up a concurrent btree.
+type latchNode[K any] struct {
+	// latch protects elements and children.
+	latch sync.RWMutex
+	// elements are ordered from least to greatest.
+	elements []K
+	// A node maintains elements + 1 children at all times.
+	children []*latchNode[K]
+}
+
+// NewConcurrent returns a tree that is safe for concurrent use with the given
+// degree where elements are ordered by cmp.
+//
+// The degree and cmp follow the same rules as New.
+func NewConcurrent[K any](degree int, cmp func(a, b K) int) (*concurrentBtree[K], error) {
+	if err := checkConfig(degree, cmp); err != nil {
+		return nil, err
+	}
+	return &concurrentBtree[K]{
+		degree: degree,
+		cmp:    cmp,
+	}, nil
+}
+
+// NewConcurrentOrdered returns a tree that is safe for concurrent use with the
+// given degree for a naturally ordered type. It behaves the same as
+// NewConcurrent with cmp.Compare as the comparator.
+func NewConcurrentOrdered[K cmp.Ordered](degree int) (*concurrentBtree[K], error) {
+	return NewConcurrent(degree, cmp.Compare[K])
+}
+
+// Len returns the count of elements in the tree.
+//
+// The complexity is O(1).
+func (bt *concurrentBtree[K]) Len() int {
+	return int(bt.len.Load())
+}
+
+// Exists checks for the existence of the given value.
+//
+// The complexity is O(log n).
+func (bt *concurrentBtree[K]) Exists(value K) bool {
+	n := bt.readRoot()
+	if n == nil {
+		return false
+	}
+	for {
+		i, found := slices.BinarySearchFunc(n.elements, value, bt.cmp)
+		if found || len(n.children) == 0 {
+			n.latch.RUnlock()
+			return found
+		}
+		// Crab down to the child. The child is latched before the node is
+		// released so a writer can not change the child in between.
+		child := n.children[i]
+		child.latch.RLock()
+		n.latch.RUnlock()
+		n = child
+	}
+}
+
+// Insert inserts an element into the tree.
+//
+// Given the value already exists in the tree, the value will still be inserted
+// as a duplicate.
+//
+// The complexity is O(log n).
+func (bt *concurrentBtree[K]) Insert(value K) {
+	defer bt.len.Add(1)
+	bt.latch.Lock()
+	// No nodes at all so create a root node.
+	if bt.root == nil {
+		bt.root = &latchNode[K]{elements: []K{value}}
+		bt.latch.Unlock()
+		return
+	}
+
+	// path holds the latched nodes an insert may still change, from the
+	// highest to the leaf. Every node in path below the first is full. indexes
+	// holds the index of each node in path within the node before it.
+	n := bt.root
+	n.latch.Lock()
+	path := []*latchNode[K]{n}
+	indexes := []int{}
+	rootLatched := true
+	if bt.safe(n) {
+		bt.latch.Unlock()
+		rootLatched = false
+	}
+	for len(n.children) != 0 {
+		i := upperBound(n.elements, value, bt.cmp)
+		child := n.children[i]
+		child.latch.Lock()
+		if bt.safe(child) {
+			// The child absorbs a split from below without splitting itself,
+			// so nothing above the child can change.
+			for _, p := range path {
+				p.latch.Unlock()
+			}
+			if rootLatched {
+				bt.latch.Unlock()
+				rootLatched = false
+			}
+			path, indexes = path[:0], indexes[:0]
+		} else {
+			indexes = append(indexes, i)
+		}
+		path = append(path, child)
+		n = child
+	}
+
+	n.elements = slices.Insert(n.elements, upperBound(n.elements, value, bt.cmp), value)
+	// Split on the way back up. Only the nodes in path can split.
+	for j := len(path) - 1; 0 < j; j-- {
+		bt.split(path[j-1], indexes[j-1])
+	}
+	if rootLatched && bt.degree <= len(bt.root.elements) {
+		root := &latchNode[K]{children: []*latchNode[K]{bt.root}}
+		bt.split(root, 0)
+		bt.root = root
+	}
+
+	for _, p := range path {
+		p.latch.Unlock()
+	}
+	if rootLatched {
+		bt.latch.Unlock()
+	}
+}
+
+// AllFunc calls fn for every element in the tree from least to greatest.
+// Iteration stops early when fn returns false.
+//
+// The iteration does not see a snapshot of the tree. Elements inserted while
+// iterating may or may not be visited. fn must not insert into the tree, as the
+// iteration holds read latches that block writers.
+//
+// The complexity is O(n).
+func (bt *concurrentBtree[K]) AllFunc(fn func(K) bool) {
+	bt.ascendFunc(nil, fn)
+}
+
+// AscendFunc calls fn for every element greater than or equal to from, from
+// least to greatest. Iteration stops early when fn returns false.
+//
+// The same rules as AllFunc apply to the iteration.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *concurrentBtree[K]) AscendFunc(from K, fn func(K) bool) {
+	bt.ascendFunc(&from, fn)
+}
+
+// RangeFunc calls fn for every element greater than or equal to lo and less
+// than hi, from least to greatest. Iteration stops early when fn returns false.
+//
+// The same rules as AllFunc apply to the iteration.
+//
+// The complexity is O(log n + k) where k is the amount of elements visited.
+func (bt *concurrentBtree[K]) RangeFunc(lo, hi K, fn func(K) bool) {
+	bt.AscendFunc(lo, func(e K) bool {
+		if 0 <= bt.cmp(e, hi) {
+			return false
+		}
+		return fn(e)
+	})
+}
+
+func (bt *concurrentBtree[K]) ascendFunc(from *K, fn func(K) bool) {
+	n := bt.readRoot()
+	if n == nil {
+		return
+	}
+	bt.ascend(n, from, fn)
+	n.latch.RUnlock()
+}
+
+// ascend visits the subtree of n in order calling fn for each element. When
+// from is given elements less than from are skipped along with the subtrees
+// that can only contain elements less than from.
+//
+// n must be read latched. Each child is read latched while it is visited, so
+// the path from the root to the current element stays latched and writers can
+// not split a node the iteration is still inside of.
+//
+// Returns false when fn stopped the iteration.
+func (bt *concurrentBtree[K]) ascend(n *latchNode[K], from *K, fn func(K) bool) bool {
+	start := 0
+	if from != nil {
+		start, _ = slices.BinarySearchFunc(n.elements, *from, bt.cmp)
+	}
+	for i := start; i <= len(n.elements); i++ {
+		if len(n.children) != 0 {
+			child := n.children[i]
+			child.latch.RLock()
+			ok := bt.ascend(child, from, fn)
+			child.latch.RUnlock()
+			if !ok {
+				return false
+			}
+		}
+		if i == len(n.elements) {
+			break
+		}
+		if !fn(n.elements[i]) {
+			return false
+		}
+		// Every element after this one is greater than or equal to from.
+		from = nil
+	}
+	return true
+}
+
+// readRoot returns the root read latched, or nil when the tree is empty.
+func (bt *concurrentBtree[K]) readRoot() *latchNode[K] {
+	bt.latch.RLock()
+	defer bt.latch.RUnlock()
+	n := bt.root
+	if n != nil {
+		n.latch.RLock()
+	}
+	return n
+}
+
+// safe reports whether an insert into the subtree of n can not split n.
+func (bt *concurrentBtree[K]) safe(n *latchNode[K]) bool {
+	return len(n.elements) < bt.degree-1
+}
+
+// split splits the child at index i of n when the child exceeds the tree's
+// degree. The child keeps the elements left of its middle element, the middle
+// element moves into n and the elements right of it move into a new node.
+//
+// n and the child must be write latched. The new node is only reachable through
+// n so it does not need to be latched.
+func (bt *concurrentBtree[K]) split(n *latchNode[K], i int) {
+	child := n.children[i]
+	if len(child.elements) < bt.degree {
+		return
+	}
+	middleIndex := (len(child.elements) - 1) / 2
+	middle := child.elements[middleIndex]
+	right := &latchNode[K]{
+		elements: slices.Clone(child.elements[middleIndex+1:]),
+	}
+	child.elements = slices.Clip(child.elements[:middleIndex])
+	if len(child.children) != 0 {
+		right.children = slices.Clone(child.children[middleIndex+1:])
+		child.children = slices.Clip(child.children[:middleIndex+1])
+	}
+	n.elements = slices.Insert(n.elements, i, middle)
+	n.children = slices.Insert(n.children, i+1, right)
+}
@@ -0,0 +1,211 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentInsert(t *testing.T) {
+	for _, degree := range []int{3, 4, 5, 16} {
+		t.Run(fmt.Sprintf("degree %v", degree), func(t *testing.T) {
+			bt, _ := NewConcurrentOrdered[int](degree)
+			want := []int{}
+			for _, v := range rand.New(rand.NewSource(1)).Perm(200) {
+				// Every value is inserted twice to cover duplicates.
+				bt.Insert(v % 100)
+				want = append(want, v%100)
+			}
+			slices.Sort(want)
+			checkConcurrent(t, bt, want)
+			for i := 0; i < 100; i++ {
+				if !bt.Exists(i) {
+					t.Fatalf("expected %v to exist", i)
+				}
+			}
+			if bt.Exists(100) {
+				t.Error("did not expect 100 to exist")
+			}
+		})
+	}
+}
+
+func TestConcurrentRange(t *testing.T) {
+	bt, _ := NewConcurrentOrdered[int](3)
+	got := []int{}
+	bt.RangeFunc(1, 5, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	if len(got) != 0 {
+		t.Errorf("expected empty tree to visit nothing got %v", got)
+	}
+	for i := 10; 0 < i; i-- {
+		bt.Insert(i)
+	}
+
+	bt.RangeFunc(3, 7, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	checkSequence(t, got, 3, 4, 5, 6)
+
+	got = got[:0]
+	bt.AscendFunc(8, func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	checkSequence(t, got, 8, 9, 10)
+
+	got = got[:0]
+	bt.AllFunc(func(e int) bool {
+		got = append(got, e)
+		return len(got) < 3
+	})
+	checkSequence(t, got, 1, 2, 3)
+}
+
+func TestNewConcurrentDegree(t *testing.T) {
+	if _, err := NewConcurrentOrdered[int](2); err == nil {
+		t.Error("expected an error for degree 2")
+	}
+	if _, err := NewConcurrent[int](3, nil); err == nil {
+		t.Error("expected an error for a nil comparator")
+	}
+}
+
+// TestConcurrentStress mixes inserts, lookups and range scans from many
+// goroutines. It is most useful with the race detector enabled:
+//
+//	go test -race ./btree -run Concurrent
+func TestConcurrentStress(t *testing.T) {
+	writers, readers, perWriter := 8, 8, 2000
+	if testing.Short() {
+		perWriter = 200
+	}
+	for _, degree := range []int{3, 4, 32} {
+		t.Run(fmt.Sprintf("degree %v", degree), func(t *testing.T) {
+			bt, _ := NewConcurrentOrdered[int](degree)
+			done := make(chan struct{})
+			var writeGroup, readGroup sync.WaitGroup
+
+			for w := 0; w < writers; w++ {
+				writeGroup.Add(1)
+				go func(w int) {
+					defer writeGroup.Done()
+					// Writers insert interleaved values so they contend on
+					// the same leaves.
+					for _, i := range rand.New(rand.NewSource(int64(w))).Perm(perWriter) {
+						v := i*writers + w
+						bt.Insert(v)
+						if !bt.Exists(v) {
+							t.Errorf("expected %v to exist after insert", v)
+							return
+						}
+					}
+				}(w)
+			}
+
+			for r := 0; r < readers; r++ {
+				readGroup.Add(1)
+				go func(r int) {
+					defer readGroup.Done()
+					rng := rand.New(rand.NewSource(int64(r)))
+					for {
+						select {
+						case <-done:
+							return
+						default:
+						}
+						if r%2 == 0 {
+							bt.Exists(rng.Intn(writers * perWriter))
+							continue
+						}
+						// Scans must always see elements in order even while
+						// nodes split around them.
+						lo := rng.Intn(writers * perWriter)
+						prev := -1
+						bt.RangeFunc(lo, lo+500, func(e int) bool {
+							if e < lo || e <= prev {
+								t.Errorf("range from %v visited %v after %v", lo, e, prev)
+								return false
+							}
+							prev = e
+							return true
+						})
+					}
+				}(r)
+			}
+
+			writeGroup.Wait()
+			close(done)
+			readGroup.Wait()
+
+			want := make([]int, writers*perWriter)
+			for i := range want {
+				want[i] = i
+			}
+			checkConcurrent(t, bt, want)
+		})
+	}
+}
+
+func BenchmarkConcurrentExists(b *testing.B) {
+	bt, _ := NewConcurrentOrdered[int](64)
+	for i := 0; i < 100000; i++ {
+		bt.Insert(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			bt.Exists(i % 100000)
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentInsert(b *testing.B) {
+	bt, _ := NewConcurrentOrdered[int](64)
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			bt.Insert(rng.Int())
+		}
+	})
+}
+
+// checkConcurrent asserts the structure of the tree is valid and it holds
+// exactly the sorted values in want. The tree must not be in use by other
+// goroutines.
+func checkConcurrent(t *testing.T, bt *concurrentBtree[int], want []int) {
+	t.Helper()
+	if bt.Len() != len(want) {
+		t.Fatalf("expected len to be %v got %v", len(want), bt.Len())
+	}
+	got := []int{}
+	bt.AllFunc(func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected elements to be %v got %v", want, got)
+	}
+	if bt.root == nil {
+		return
+	}
+	checker := newNodeChecker(bt.degree, bt.cmp)
+	var check func(n *latchNode[int], path string, lo, hi *int, depth int)
+	check = func(n *latchNode[int], path string, lo, hi *int, depth int) {
+		if err := checker.check(path, n.elements, len(n.children), lo, hi, depth); err != nil {
+			t.Fatal(err)
+		}
+		for i, c := range n.children {
+			childLo, childHi := childBounds(n.elements, i, lo, hi)
+			check(c, fmt.Sprintf("%v.children[%v]", path, i), childLo, childHi, depth+1)
+		}
+	}
+	check(bt.root, "root", nil, nil, 0)
+}
@@ -0,0 +1,740 @@
+package btree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Codec converts elements to and from a fixed amount of bytes so they can be
+// stored in pages.
+type Codec[K any] interface {
+	// Size is the amount of bytes every encoded element takes.
+	Size() int
+	// Encode writes value to the first Size bytes of dst.
+	Encode(dst []byte, value K)
+	// Decode reads a value from the first Size bytes of src.
+	Decode(src []byte) K
+}
+
+// Int64Codec encodes int64 elements as 8 little endian bytes.
+type Int64Codec struct{}
+
+func (Int64Codec) Size() int {
+	return 8
+}
+
+func (Int64Codec) Encode(dst []byte, value int64) {
+	binary.LittleEndian.PutUint64(dst, uint64(value))
+}
+
+func (Int64Codec) Decode(src []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(src))
+}
+
+// diskTree is a btree stored in a file so it can grow larger than memory.
+//
+// Every node is stored in its own fixed size page and refers to its children by
+// page id. Pages are read and written through a pager, which keeps the most
+// recently used pages in a buffer pool. Changes are only guaranteed to be in
+// the file after Flush or Close.
+//
+// The first page of the file is the meta page, which holds the root page id,
+// the count of elements and the list of freed pages. Nodes live in the pages
+// after it.
+type diskTree[K any] struct {
+	pager *pager
+	meta  meta
+	// degree is the maximum amount of elements a node can contain. It is the
+	// most that fits in a page.
+	degree int
+	cmp    func(a, b K) int
+	codec  Codec[K]
+}
+
+// meta is the content of the meta page.
+type meta struct {
+	// root is the page of the root node, 0 when the tree is empty.
+	root pageID
+	// free is the first page of the list of freed pages, 0 when no page is
+	// free. Each free page holds the id of the next free page.
+	free pageID
+	// len is the count of elements in the tree.
+	len uint64
+}
+
+// diskNode is a node decoded from a page.
+type diskNode[K any] struct {
+	id       pageID
+	elements []K
+	children []pageID
+}
+
+const (
+	// metaPage is the id of the meta page.
+	metaPage pageID = 0
+	// magic identifies a file holding a disk tree.
+	magic = "BTPG"
+	// nodeHeaderSize is the amount of bytes at the start of a node page
+	// before the elements. It holds the page kind and the element count.
+	nodeHeaderSize = 4
+	// childSize is the amount of bytes taken by a child page id.
+	childSize = 4
+	// metaSize is the amount of bytes used in the meta page.
+	metaSize = 28
+)
+
+// The kind of a page is stored in its first byte.
+const (
+	leafPage     = 1
+	internalPage = 2
+	freePage     = 3
+)
+
+// OpenDisk opens the disk tree stored in the file at path, creating the file
+// when it does not exist. Changes are written through a WAL next to the file,
+// which OpenDisk recovers from after a crash.
+//
+// pageSize is the size of every page in bytes. The degree of the tree is the
+// most elements a page of this size can hold along with the page ids of their
+// children, which must be at least 3. An existing file must be opened with the
+// page size and element size it was created with.
+//
+// poolSize is the amount of pages the buffer pool keeps in memory.
+//
+// cmp follows the same rules as New.
+func OpenDisk[K any](
+	path string,
+	pageSize int,
+	poolSize int,
+	codec Codec[K],
+	cmp func(a, b K) int,
+) (*diskTree[K], error) {
+	if codec == nil || codec.Size() <= 0 {
+		return nil, errors.New("codec must encode elements to at least 1 byte")
+	}
+	if pageSize < metaSize {
+		return nil, fmt.Errorf("page size %v is less than the meta page size %v", pageSize, metaSize)
+	}
+	// A node holds up to degree-1 elements and degree children. The element
+	// count of a page is stored in 2 bytes.
+	degree := (pageSize - nodeHeaderSize + codec.Size()) / (codec.Size() + childSize)
+	degree = min(degree, math.MaxUint16+1)
+	if err := checkConfig(degree, cmp); err != nil {
+		return nil, fmt.Errorf("page size %v: %w", pageSize, err)
+	}
+	p, err := openPager(path, pageSize, poolSize)
+	if err != nil {
+		return nil, err
+	}
+	t := &diskTree[K]{
+		pager:  p,
+		degree: degree,
+		cmp:    cmp,
+		codec:  codec,
+	}
+	if err := t.readMeta(); err != nil {
+		p.closeFiles()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Len returns the count of elements in the tree.
+//
+// The complexity is O(1).
+func (t *diskTree[K]) Len() int {
+	return int(t.meta.len)
+}
+
+// Flush writes every change to the file and syncs it to stable storage. The
+// changes since the last flush are atomic, after a crash the tree holds either
+// all or none of them.
+func (t *diskTree[K]) Flush() error {
+	if err := t.writeMeta(); err != nil {
+		return err
+	}
+	return t.pager.flush()
+}
+
+// Close flushes the tree and closes the file. The tree must not be used after
+// it is closed.
+func (t *diskTree[K]) Close() error {
+	if err := t.writeMeta(); err != nil {
+		t.pager.closeFiles()
+		return err
+	}
+	return t.pager.close()
+}
+
+// Exists checks for the existence of the given value.
+//
+// The complexity is O(log n) page reads.
+func (t *diskTree[K]) Exists(value K) (bool, error) {
+	id := t.meta.root
+	for id != 0 {
+		n, err := t.load(id)
+		if err != nil {
+			return false, err
+		}
+		i, found := slices.BinarySearchFunc(n.elements, value, t.cmp)
+		if found {
+			return true, nil
+		}
+		if len(n.children) == 0 {
+			return false, nil
+		}
+		id = n.children[i]
+	}
+	return false, nil
+}
+
+// Insert inserts an element into the tree.
+//
+// Given the value already exists in the tree, the value will still be inserted
+// as a duplicate.
+//
+// The complexity is O(log n) page reads and writes.
+func (t *diskTree[K]) Insert(value K) error {
+	if t.meta.root == 0 {
+		root, err := t.allocate()
+		if err != nil {
+			return err
+		}
+		root.elements = []K{value}
+		if err := t.store(root); err != nil {
+			return err
+		}
+		t.meta.root = root.id
+		t.meta.len++
+		return nil
+	}
+	root, err := t.load(t.meta.root)
+	if err != nil {
+		return err
+	}
+	if err := t.insert(root, value); err != nil {
+		return err
+	}
+	// Splitting the root is what grows the tree in height.
+	if t.degree <= len(root.elements) {
+		newRoot, err := t.allocate()
+		if err != nil {
+			return err
+		}
+		newRoot.children = []pageID{root.id}
+		if err := t.split(newRoot, 0, root); err != nil {
+			return err
+		}
+		if err := t.store(newRoot); err != nil {
+			return err
+		}
+		t.meta.root = newRoot.id
+	}
+	t.meta.len++
+	return nil
+}
+
+// insert recursively follows nodes until hitting a leaf and inserts the value
+// there. Children that exceed the degree are split on the way back up.
+//
+// Only changed nodes are stored. A node that exceeds the degree does not fit
+// its page, so it is left for the caller to split and store.
+func (t *diskTree[K]) insert(n *diskNode[K], value K) error {
+	i := upperBound(n.elements, value, t.cmp)
+	if len(n.children) == 0 {
+		n.elements = slices.Insert(n.elements, i, value)
+		return t.storeFitting(n)
+	}
+	child, err := t.load(n.children[i])
+	if err != nil {
+		return err
+	}
+	if err := t.insert(child, value); err != nil {
+		return err
+	}
+	if len(child.elements) < t.degree {
+		return nil
+	}
+	if err := t.split(n, i, child); err != nil {
+		return err
+	}
+	return t.storeFitting(n)
+}
+
+// split splits child, the child at index i of n, which exceeds the degree. The
+// child keeps the elements left of its middle element, the middle element moves
+// into n and the elements right of it move into a new page.
+//
+// The child and the new page are stored, n is left for the caller to store.
+func (t *diskTree[K]) split(n *diskNode[K], i int, child *diskNode[K]) error {
+	right, err := t.allocate()
+	if err != nil {
+		return err
+	}
+	middleIndex := (len(child.elements) - 1) / 2
+	middle := child.elements[middleIndex]
+	right.elements = slices.Clone(child.elements[middleIndex+1:])
+	child.elements = child.elements[:middleIndex]
+	if len(child.children) != 0 {
+		right.children = slices.Clone(child.children[middleIndex+1:])
+		child.children = child.children[:middleIndex+1]
+	}
+	if err := t.store(child); err != nil {
+		return err
+	}
+	if err := t.store(right); err != nil {
+		return err
+	}
+	n.elements = slices.Insert(n.elements, i, middle)
+	n.children = slices.Insert(n.children, i+1, right.id)
+	return nil
+}
+
+// Delete removes a single occurrence of the given value from the tree.
+//
+// Returns true when the value existed and was removed.
+//
+// The complexity is O(log n) page reads and writes.
+func (t *diskTree[K]) Delete(value K) (bool, error) {
+	// Checking first means nothing is written when the value does not exist.
+	if ok, err := t.Exists(value); !ok || err != nil {
+		return false, err
+	}
+	root, err := t.load(t.meta.root)
+	if err != nil {
+		return false, err
+	}
+	if err := t.delete(root, value); err != nil {
+		return false, err
+	}
+	t.meta.len--
+	if len(root.elements) != 0 {
+		return true, t.store(root)
+	}
+	// An empty root either empties the tree or is replaced by its only child,
+	// which is what shrinks the tree in height.
+	t.meta.root = 0
+	if len(root.children) != 0 {
+		t.meta.root = root.children[0]
+	}
+	return true, t.free(root.id)
+}
+
+// delete recursively removes a single occurrence of value from the subtree of
+// n, which must contain the value. On the way back up each child that fell
+// below the minimum amount of elements is rebalanced.
+//
+// Every changed node other than n is stored, n is left for the caller to
+// store.
+func (t *diskTree[K]) delete(n *diskNode[K], value K) error {
+	i, found := slices.BinarySearchFunc(n.elements, value, t.cmp)
+	if len(n.children) == 0 {
+		n.elements = slices.Delete(n.elements, i, i+1)
+		return nil
+	}
+	child, err := t.load(n.children[i])
+	if err != nil {
+		return err
+	}
+	if found {
+		// The value is in an internal node. Replace it with its in order
+		// predecessor, which is always the last element of a leaf, so the
+		// removal itself always happens on a leaf.
+		n.elements[i], err = t.deleteMax(child)
+	} else {
+		err = t.delete(child, value)
+	}
+	if err != nil {
+		return err
+	}
+	return t.rebalance(n, i, child)
+}
+
+// deleteMax removes and returns the greatest element in the subtree of n.
+//
+// Every changed node other than n is stored, n is left for the caller to
+// store.
+func (t *diskTree[K]) deleteMax(n *diskNode[K]) (K, error) {
+	if len(n.children) == 0 {
+		last := n.elements[len(n.elements)-1]
+		n.elements = n.elements[:len(n.elements)-1]
+		return last, nil
+	}
+	i := len(n.children) - 1
+	child, err := t.load(n.children[i])
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+	last, err := t.deleteMax(child)
+	if err != nil {
+		return last, err
+	}
+	return last, t.rebalance(n, i, child)
+}
+
+// rebalance stores child, the child at index i of n, after fixing it when it
+// has fallen below the minimum amount of elements. The child first tries to
+// borrow an element from a sibling through n. When neither sibling can spare an
+// element the child is merged with a sibling, which takes an element from n and
+// frees the page of the sibling on the right.
+//
+// Changed siblings are stored, n is left for the caller to store.
+func (t *diskTree[K]) rebalance(n *diskNode[K], i int, child *diskNode[K]) error {
+	if minElements(t.degree) <= len(child.elements) {
+		return t.store(child)
+	}
+	var left, right *diskNode[K]
+	var err error
+	if 0 < i {
+		if left, err = t.load(n.children[i-1]); err != nil {
+			return err
+		}
+		if minElements(t.degree) < len(left.elements) {
+			n.elements[i-1] = rotate(left, child, n.elements[i-1], false)
+			return t.store(left, child)
+		}
+	}
+	if i+1 < len(n.children) {
+		if right, err = t.load(n.children[i+1]); err != nil {
+			return err
+		}
+		if minElements(t.degree) < len(right.elements) {
+			n.elements[i] = rotate(child, right, n.elements[i], true)
+			return t.store(child, right)
+		}
+	}
+	if left != nil {
+		right, i = child, i-1
+	} else {
+		left = child
+	}
+	// Merge the right node into the left node. This is the reverse of split.
+	left.elements = append(left.elements, n.elements[i])
+	left.elements = append(left.elements, right.elements...)
+	left.children = append(left.children, right.children...)
+	n.elements = slices.Delete(n.elements, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+	if err := t.store(left); err != nil {
+		return err
+	}
+	return t.free(right.id)
+}
+
+// rotate moves an element between the adjacent nodes left and right through
+// the separator between them and returns the new separator. When toLeft is
+// true the first element of right moves, otherwise the last element of left
+// moves. A child moves along with the element when the nodes are internal.
+func rotate[K any](left, right *diskNode[K], separator K, toLeft bool) K {
+	if toLeft {
+		left.elements = append(left.elements, separator)
+		separator = right.elements[0]
+		right.elements = slices.Delete(right.elements, 0, 1)
+		if len(right.children) != 0 {
+			left.children = append(left.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return separator
+	}
+	last := len(left.elements) - 1
+	right.elements = slices.Insert(right.elements, 0, separator)
+	separator = left.elements[last]
+	left.elements = left.elements[:last]
+	if len(left.children) != 0 {
+		right.children = slices.Insert(right.children, 0, left.children[last+1])
+		left.children = left.children[:last+1]
+	}
+	return separator
+}
+
+// AllFunc calls fn for every element in the tree from least to greatest.
+// Iteration stops early when fn returns false.
+//
+// The complexity is O(n) page reads.
+func (t *diskTree[K]) AllFunc(fn func(K) bool) error {
+	if t.meta.root == 0 {
+		return nil
+	}
+	_, err := t.ascend(t.meta.root, nil, fn)
+	return err
+}
+
+// RangeFunc calls fn for every element greater than or equal to lo and less
+// than hi, from least to greatest. Iteration stops early when fn returns false.
+//
+// The complexity is O(log n + k) page reads where k is the amount of elements
+// visited.
+func (t *diskTree[K]) RangeFunc(lo, hi K, fn func(K) bool) error {
+	if t.meta.root == 0 {
+		return nil
+	}
+	_, err := t.ascend(t.meta.root, &lo, func(e K) bool {
+		if 0 <= t.cmp(e, hi) {
+			return false
+		}
+		return fn(e)
+	})
+	return err
+}
+
+// ascend visits the subtree of the node in page id in order calling fn for
+// each element. When from is given elements less than from are skipped along
+// with the subtrees that can only contain elements less than from.
+//
+// Returns false when fn stopped the iteration.
+func (t *diskTree[K]) ascend(id pageID, from *K, fn func(K) bool) (bool, error) {
+	// The node is decoded so it does not depend on its page staying in the
+	// buffer pool while its children are visited.
+	n, err := t.load(id)
+	if err != nil {
+		return false, err
+	}
+	start := 0
+	if from != nil {
+		start, _ = slices.BinarySearchFunc(n.elements, *from, t.cmp)
+	}
+	for i := start; i <= len(n.elements); i++ {
+		if len(n.children) != 0 {
+			ok, err := t.ascend(n.children[i], from, fn)
+			if !ok || err != nil {
+				return false, err
+			}
+		}
+		if i == len(n.elements) {
+			break
+		}
+		if !fn(n.elements[i]) {
+			return false, nil
+		}
+		// Every element after this one is greater than or equal to from.
+		from = nil
+	}
+	return true, nil
+}
+
+// Validate checks the structure of the tree and returns an error describing the
+// first violation found, or nil when the tree is valid.
+//
+// The same rules as btree's Validate are checked, except that the count of
+// elements is checked for the whole tree. Additionally every node must be in
+// its own page.
+//
+// The complexity is O(n) page reads.
+func (t *diskTree[K]) Validate() error {
+	if t.meta.root == 0 {
+		if t.meta.len != 0 {
+			return fmt.Errorf("tree is empty, the len is %v", t.meta.len)
+		}
+		return nil
+	}
+	v := diskValidator[K]{
+		nodeChecker: newNodeChecker(t.degree, t.cmp),
+		t:           t,
+		seen:        map[pageID]bool{},
+	}
+	if err := v.validate(t.meta.root, "root", nil, nil, 0); err != nil {
+		return err
+	}
+	if v.count != t.meta.len {
+		return fmt.Errorf("tree has %v elements, the len is %v", v.count, t.meta.len)
+	}
+	return nil
+}
+
+// diskValidator holds the state shared while validating a disk tree.
+type diskValidator[K any] struct {
+	nodeChecker[K]
+	t *diskTree[K]
+	// count is the amount of elements found so far.
+	count uint64
+	// seen holds the pages of the nodes found so far.
+	seen map[pageID]bool
+}
+
+// validate recursively checks the subtree of the node in page id. lo and hi
+// are the elements of the parent surrounding the node, nil when there is no
+// element on that side.
+func (v *diskValidator[K]) validate(id pageID, path string, lo, hi *K, depth int) error {
+	if v.seen[id] {
+		return fmt.Errorf("%v: page %v is used by more than one node", path, id)
+	}
+	v.seen[id] = true
+	n, err := v.t.load(id)
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+	if err := v.check(path, n.elements, len(n.children), lo, hi, depth); err != nil {
+		return err
+	}
+	v.count += uint64(len(n.elements))
+	for i, c := range n.children {
+		childLo, childHi := childBounds(n.elements, i, lo, hi)
+		childPath := fmt.Sprintf("%v.children[%v]", path, i)
+		if err := v.validate(c, childPath, childLo, childHi, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load reads and decodes the node in page id.
+func (t *diskTree[K]) load(id pageID) (*diskNode[K], error) {
+	f, err := t.pager.get(id)
+	if err != nil {
+		return nil, err
+	}
+	page := f.data
+	kind := page[0]
+	if kind != leafPage && kind != internalPage {
+		return nil, fmt.Errorf("page %v is not a node", id)
+	}
+	count := int(binary.LittleEndian.Uint16(page[2:]))
+	if t.degree <= count {
+		return nil, fmt.Errorf("page %v has %v elements, the degree is %v", id, count, t.degree)
+	}
+	size := t.codec.Size()
+	n := &diskNode[K]{
+		id:       id,
+		elements: make([]K, count),
+	}
+	offset := nodeHeaderSize
+	for i := range n.elements {
+		n.elements[i] = t.codec.Decode(page[offset : offset+size])
+		offset += size
+	}
+	if kind == internalPage {
+		n.children = make([]pageID, count+1)
+		for i := range n.children {
+			n.children[i] = pageID(binary.LittleEndian.Uint32(page[offset:]))
+			offset += childSize
+		}
+	}
+	return n, nil
+}
+
+// store encodes nodes into their pages.
+func (t *diskTree[K]) store(nodes ...*diskNode[K]) error {
+	for _, n := range nodes {
+		f, err := t.pager.get(n.id)
+		if err != nil {
+			return err
+		}
+		page := f.data
+		clear(page)
+		page[0] = leafPage
+		if len(n.children) != 0 {
+			page[0] = internalPage
+		}
+		binary.LittleEndian.PutUint16(page[2:], uint16(len(n.elements)))
+		size := t.codec.Size()
+		offset := nodeHeaderSize
+		for _, e := range n.elements {
+			t.codec.Encode(page[offset:offset+size], e)
+			offset += size
+		}
+		for _, c := range n.children {
+			binary.LittleEndian.PutUint32(page[offset:], uint32(c))
+			offset += childSize
+		}
+		f.dirty = true
+	}
+	return nil
+}
+
+// storeFitting stores n when it does not exceed the degree.
+func (t *diskTree[K]) storeFitting(n *diskNode[K]) error {
+	if t.degree <= len(n.elements) {
+		return nil
+	}
+	return t.store(n)
+}
+
+// allocate returns an empty node in a new page. A freed page is reused before
+// the file is extended.
+func (t *diskTree[K]) allocate() (*diskNode[K], error) {
+	if t.meta.free == 0 {
+		f, err := t.pager.allocate()
+		if err != nil {
+			return nil, err
+		}
+		return &diskNode[K]{id: f.id}, nil
+	}
+	id := t.meta.free
+	f, err := t.pager.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if f.data[0] != freePage {
+		return nil, fmt.Errorf("page %v is in the free list but is not free", id)
+	}
+	t.meta.free = pageID(binary.LittleEndian.Uint32(f.data[4:]))
+	return &diskNode[K]{id: id}, nil
+}
+
+// free adds page id to the free list.
+func (t *diskTree[K]) free(id pageID) error {
+	f, err := t.pager.get(id)
+	if err != nil {
+		return err
+	}
+	clear(f.data)
+	f.data[0] = freePage
+	binary.LittleEndian.PutUint32(f.data[4:], uint32(t.meta.free))
+	f.dirty = true
+	t.meta.free = id
+	return nil
+}
+
+// readMeta reads the meta page, or creates it when the file is empty.
+//
+// The meta page is laid out as the magic, the page size, the element size, the
+// root page, the first free page and the count of elements.
+func (t *diskTree[K]) readMeta() error {
+	if t.pager.pages == 0 {
+		if _, err := t.pager.allocate(); err != nil {
+			return err
+		}
+		return t.writeMeta()
+	}
+	f, err := t.pager.get(metaPage)
+	if err != nil {
+		return err
+	}
+	page := f.data
+	if string(page[:4]) != magic {
+		return errors.New("file is not a disk tree")
+	}
+	if pageSize := int(binary.LittleEndian.Uint32(page[4:])); pageSize != t.pager.pageSize {
+		return fmt.Errorf("file has a page size of %v, not %v", pageSize, t.pager.pageSize)
+	}
+	if size := int(binary.LittleEndian.Uint32(page[8:])); size != t.codec.Size() {
+		return fmt.Errorf("file has an element size of %v, not %v", size, t.codec.Size())
+	}
+	t.meta = meta{
+		root: pageID(binary.LittleEndian.Uint32(page[12:])),
+		free: pageID(binary.LittleEndian.Uint32(page[16:])),
+		len:  binary.LittleEndian.Uint64(page[20:]),
+	}
+	return nil
+}
+
+// writeMeta writes the meta page. It is written on flush rather than on every
+// change since the meta changes with nearly every operation.
+func (t *diskTree[K]) writeMeta() error {
+	f, err := t.pager.get(metaPage)
+	if err != nil {
+		return err
+	}
+	page := f.data
+	copy(page, magic)
+	binary.LittleEndian.PutUint32(page[4:], uint32(t.pager.pageSize))
+	binary.LittleEndian.PutUint32(page[8:], uint32(t.codec.Size()))
+	binary.LittleEndian.PutUint32(page[12:], uint32(t.meta.root))
+	binary.LittleEndian.PutUint32(page[16:], uint32(t.meta.free))
+	binary.LittleEndian.PutUint64(page[20:], t.meta.len)
+	f.dirty = true
+	return nil
+}
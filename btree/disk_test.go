@@ -0,0 +1,209 @@
+package btree
+
+import (
+	"cmp"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// openTestDisk opens a disk tree with a degree of 5 and a buffer pool of 4
+// pages, so most operations have to read and evict pages.
+func openTestDisk(t *testing.T, path string) *diskTree[int64] {
+	t.Helper()
+	dt, err := OpenDisk[int64](path, 64, 4, Int64Codec{}, cmp.Compare[int64])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt.degree != 5 {
+		t.Fatalf("expected degree 5 got %v", dt.degree)
+	}
+	return dt
+}
+
+func TestDiskOperations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openTestDisk(t, path)
+	rng := rand.New(rand.NewSource(1))
+	model := []int64{}
+	for i := 0; i < 3000; i++ {
+		v := int64(rng.Intn(200))
+		if rng.Intn(3) == 0 {
+			j, want := slices.BinarySearch(model, v)
+			if want {
+				model = slices.Delete(model, j, j+1)
+			}
+			got, err := dt.Delete(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Fatalf("expected delete %v to be %v got %v", v, want, got)
+			}
+		} else {
+			if err := dt.Insert(v); err != nil {
+				t.Fatal(err)
+			}
+			j := sort.Search(len(model), func(j int) bool { return v < model[j] })
+			model = slices.Insert(model, j, v)
+		}
+		if i%100 == 0 {
+			checkDiskModel(t, dt, model)
+		}
+	}
+	checkDiskModel(t, dt, model)
+	for _, v := range []int64{-1, 200} {
+		if ok, err := dt.Exists(v); ok || err != nil {
+			t.Errorf("expected %v to not exist got %v, %v", v, ok, err)
+		}
+	}
+	if err := dt.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiskPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openTestDisk(t, path)
+	model := []int64{}
+	for i := int64(0); i < 500; i++ {
+		dt.Insert(i)
+		model = append(model, i)
+	}
+	if err := dt.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dt = openTestDisk(t, path)
+	checkDiskModel(t, dt, model)
+	for i := int64(0); i < 500; i += 2 {
+		dt.Delete(i)
+	}
+	model = model[:0]
+	for i := int64(1); i < 500; i += 2 {
+		model = append(model, i)
+	}
+	// Flush without closing, then read the file with a second tree.
+	if err := dt.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	other := openTestDisk(t, path)
+	checkDiskModel(t, other, model)
+	other.Close()
+	dt.Close()
+}
+
+func TestDiskFreePages(t *testing.T) {
+	dt := openTestDisk(t, filepath.Join(t.TempDir(), "tree"))
+	defer dt.Close()
+	for i := int64(0); i < 300; i++ {
+		dt.Insert(i)
+	}
+	pages := dt.pager.pages
+	for i := int64(0); i < 300; i++ {
+		if ok, err := dt.Delete(i); !ok || err != nil {
+			t.Fatalf("expected %v to be deleted got %v, %v", i, ok, err)
+		}
+	}
+	if dt.meta.root != 0 {
+		t.Error("expected the tree to be empty")
+	}
+	// Every page freed by the deletes is reused before the file grows.
+	model := []int64{}
+	for i := int64(0); i < 300; i++ {
+		dt.Insert(i)
+		model = append(model, i)
+	}
+	if dt.pager.pages != pages {
+		t.Errorf("expected %v pages got %v", pages, dt.pager.pages)
+	}
+	checkDiskModel(t, dt, model)
+}
+
+func TestDiskRange(t *testing.T) {
+	dt := openTestDisk(t, filepath.Join(t.TempDir(), "tree"))
+	defer dt.Close()
+	for i := int64(100); 0 < i; i-- {
+		dt.Insert(i)
+	}
+	got := []int64{}
+	err := dt.RangeFunc(40, 45, func(e int64) bool {
+		got = append(got, e)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []int64{40, 41, 42, 43, 44}) {
+		t.Errorf("expected range to be [40 41 42 43 44] got %v", got)
+	}
+}
+
+func TestOpenDiskErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := OpenDisk[int64](filepath.Join(dir, "small"), 24, 4, Int64Codec{}, cmp.Compare[int64]); err == nil {
+		t.Error("expected an error for a page that is too small")
+	}
+	if _, err := OpenDisk[int64](filepath.Join(dir, "nil"), 64, 4, Int64Codec{}, nil); err == nil {
+		t.Error("expected an error for a nil comparator")
+	}
+
+	path := filepath.Join(dir, "tree")
+	dt := openTestDisk(t, path)
+	dt.Insert(1)
+	dt.Close()
+	if _, err := OpenDisk[int64](path, 32, 4, Int64Codec{}, cmp.Compare[int64]); err == nil {
+		t.Error("expected an error for a different page size")
+	}
+
+	other := filepath.Join(dir, "other")
+	if err := os.WriteFile(other, make([]byte, 64), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenDisk[int64](other, 64, 4, Int64Codec{}, cmp.Compare[int64]); err == nil {
+		t.Error("expected an error for a file that is not a disk tree")
+	}
+}
+
+func BenchmarkDiskInsert(b *testing.B) {
+	dt, err := OpenDisk[int64](filepath.Join(b.TempDir(), "tree"), 4096, 64, Int64Codec{}, cmp.Compare[int64])
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer dt.Close()
+	rng := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dt.Insert(rng.Int63())
+	}
+}
+
+// checkDiskModel asserts the tree is valid and holds exactly the sorted model.
+func checkDiskModel(t *testing.T, dt *diskTree[int64], model []int64) {
+	t.Helper()
+	if err := dt.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if dt.Len() != len(model) {
+		t.Fatalf("expected len to be %v got %v", len(model), dt.Len())
+	}
+	got := []int64{}
+	err := dt.AllFunc(func(e int64) bool {
+		got = append(got, e)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, model) {
+		t.Fatalf("expected elements to be %v got %v", model, got)
+	}
+	for _, v := range model {
+		if ok, err := dt.Exists(v); !ok || err != nil {
+			t.Fatalf("expected %v to exist got %v, %v", v, ok, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package btree
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// FuzzBTreeOps decodes data into a sequence of operations on a tree and checks
+// the tree against a sorted slice after every operation.
+//
+// The first byte picks the degree. Every following pair of bytes is an
+// operation followed by a value.
+func FuzzBTreeOps(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == 0 {
+			return
+		}
+		degree := 3 + int(data[0]%14)
+		data = data[1:]
+		bt, err := NewOrdered[int](degree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		model := []int{}
+		for 2 <= len(data) {
+			op, value := data[0]%3, int(data[1]%64)
+			data = data[2:]
+			switch op {
+			case 0:
+				bt.Insert(value)
+				model = slices.Insert(model, sort.SearchInts(model, value+1), value)
+			case 1:
+				want := slices.Contains(model, value)
+				if got := bt.Exists(value); got != want {
+					t.Fatalf("expected exists %v to be %v got %v", value, want, got)
+				}
+			case 2:
+				i, want := slices.BinarySearch(model, value)
+				if want {
+					model = slices.Delete(model, i, i+1)
+				}
+				if got := bt.Delete(value); got != want {
+					t.Fatalf("expected delete %v to be %v got %v", value, want, got)
+				}
+			}
+			checkModel(t, bt, model)
+		}
+	})
+}
+
+// checkModel asserts the tree is valid and holds exactly the sorted model.
+func checkModel(t *testing.T, bt *btree[int], model []int) {
+	t.Helper()
+	if err := bt.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if bt.Len() != len(model) {
+		t.Fatalf("expected len to be %v got %v", len(model), bt.Len())
+	}
+	got := []int{}
+	bt.AllFunc(func(e int) bool {
+		got = append(got, e)
+		return true
+	})
+	if !slices.Equal(got, model) {
+		t.Fatalf("expected elements to be %v got %v", model, got)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package btree
+
+import "iter"
+
+// All returns an iterator over every element in the tree from least to
+// greatest.
+func (bt *btree[K]) All() iter.Seq[K] {
+	return bt.AllFunc
+}
+
+// Ascend returns an iterator over every element greater than or equal to from,
+// from least to greatest.
+func (bt *btree[K]) Ascend(from K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.AscendFunc(from, yield)
+	}
+}
+
+// Descend returns an iterator over every element less than or equal to from,
+// from greatest to least.
+func (bt *btree[K]) Descend(from K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.DescendFunc(from, yield)
+	}
+}
+
+// Range returns an iterator over every element greater than or equal to lo and
+// less than hi, from least to greatest.
+func (bt *btree[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.RangeFunc(lo, hi, yield)
+	}
+}
+
+// All returns an iterator over every element in the tree from least to
+// greatest.
+func (bt *bplusTree[K]) All() iter.Seq[K] {
+	return bt.AllFunc
+}
+
+// Ascend returns an iterator over every element greater than or equal to from,
+// from least to greatest.
+func (bt *bplusTree[K]) Ascend(from K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.AscendFunc(from, yield)
+	}
+}
+
+// Descend returns an iterator over every element less than or equal to from,
+// from greatest to least.
+func (bt *bplusTree[K]) Descend(from K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.DescendFunc(from, yield)
+	}
+}
+
+// Range returns an iterator over every element greater than or equal to lo and
+// less than hi, from least to greatest.
+func (bt *bplusTree[K]) Range(lo, hi K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.RangeFunc(lo, hi, yield)
+	}
+}
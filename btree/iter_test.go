@@ -0,0 +1,59 @@
+//go:build go1.23
+
+package btree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	bt, _ := NewOrdered(3, 5, 3, 9, 1, 7, 8, 2, 6, 4, 0)
+
+	t.Run("all", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.All()), 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	})
+
+	t.Run("ascend", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Ascend(7)), 7, 8, 9)
+	})
+
+	t.Run("descend", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Descend(2)), 2, 1, 0)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Range(3, 6)), 3, 4, 5)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		got := []int{}
+		for e := range bt.All() {
+			if e == 4 {
+				break
+			}
+			got = append(got, e)
+		}
+		checkSequence(t, got, 0, 1, 2, 3)
+	})
+}
+
+func TestBPlusIterators(t *testing.T) {
+	bt, _ := NewBPlusOrdered(3, 5, 3, 9, 1, 7, 8, 2, 6, 4, 0)
+
+	t.Run("all", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.All()), 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	})
+
+	t.Run("ascend", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Ascend(7)), 7, 8, 9)
+	})
+
+	t.Run("descend", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Descend(2)), 2, 1, 0)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		checkSequence(t, slices.Collect(bt.Range(3, 6)), 3, 4, 5)
+	})
+}
@@ -0,0 +1,209 @@
+package btree
+
+import (
+	"cmp"
+)
+
+// DuplicatePolicy determines what a map does when a key is put that already
+// exists in the map.
+type DuplicatePolicy int
+
+const (
+	// Replace overwrites the value of the existing key.
+	Replace DuplicatePolicy = iota
+	// Reject keeps the value of the existing key and discards the new value.
+	Reject
+	// Multi keeps every value put for a key, making the map a multimap. Values
+	// of the same key are kept in the order they were put.
+	Multi
+)
+
+// btreeMap is an ordered key value map backed by a btree.
+type btreeMap[K, V any] struct {
+	// tree stores each key together with its value as a single element so
+	// splitting, borrowing and merging nodes moves values along with keys.
+	tree *btree[entry[K, V]]
+	// duplicates is how a put for an existing key is handled.
+	duplicates DuplicatePolicy
+}
+
+// entry is a single key value pair of a map.
+type entry[K, V any] struct {
+	key   K
+	value V
+}
+
+// NewMap returns a map with the given degree where keys are ordered by cmp and
+// a put for an existing key is handled according to duplicates.
+//
+// The degree and cmp follow the same rules as New.
+func NewMap[K, V any](
+	degree int,
+	cmp func(a, b K) int,
+	duplicates DuplicatePolicy,
+) (*btreeMap[K, V], error) {
+	var keyCmp func(a, b entry[K, V]) int
+	if cmp != nil {
+		keyCmp = func(a, b entry[K, V]) int {
+			return cmp(a.key, b.key)
+		}
+	}
+	bt, err := New(degree, keyCmp)
+	if err != nil {
+		return nil, err
+	}
+	return &btreeMap[K, V]{
+		tree:       bt,
+		duplicates: duplicates,
+	}, nil
+}
+
+// NewOrderedMap returns a map with the given degree for a naturally ordered key
+// type. It behaves the same as NewMap with cmp.Compare as the comparator.
+func NewOrderedMap[K cmp.Ordered, V any](
+	degree int,
+	duplicates DuplicatePolicy,
+) (*btreeMap[K, V], error) {
+	return NewMap[K, V](degree, cmp.Compare[K], duplicates)
+}
+
+// Len returns the count of values in the map.
+//
+// The complexity is O(1).
+func (m *btreeMap[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// Put associates the value with the key.
+//
+// Returns false when the key already exists and the map rejects duplicates.
+//
+// The complexity is O(log n).
+func (m *btreeMap[K, V]) Put(key K, value V) bool {
+	e := entry[K, V]{key: key, value: value}
+	switch m.duplicates {
+	case Replace:
+		if root, ok := m.tree.replaceFirst(m.tree.root, e); ok {
+			m.tree.root = root
+			return true
+		}
+	case Reject:
+		if n, _ := m.tree.findFirst(m.tree.root, e); n != nil {
+			return false
+		}
+	}
+	m.tree.Insert(e)
+	return true
+}
+
+// Get returns the value associated with the key and whether the key exists.
+//
+// When the map is a multimap the first value put for the key is returned.
+//
+// The complexity is O(log n).
+func (m *btreeMap[K, V]) Get(key K) (V, bool) {
+	n, i := m.tree.findFirst(m.tree.root, entry[K, V]{key: key})
+	if n == nil {
+		var zero V
+		return zero, false
+	}
+	return n.elements[i].value, true
+}
+
+// GetAll returns every value associated with the key in the order they were
+// put.
+//
+// The complexity is O(log n + k) where k is the amount of values for the key.
+func (m *btreeMap[K, V]) GetAll(key K) []V {
+	values := []V{}
+	m.tree.eachEqual(m.tree.root, entry[K, V]{key: key}, func(e entry[K, V]) {
+		values = append(values, e.value)
+	})
+	return values
+}
+
+// Delete removes the key and every value associated with it.
+//
+// Returns true when the key existed.
+//
+// The complexity is O(k log n) where k is the amount of values for the key.
+func (m *btreeMap[K, V]) Delete(key K) bool {
+	deleted := false
+	for m.tree.Delete(entry[K, V]{key: key}) {
+		deleted = true
+	}
+	return deleted
+}
+
+// Clone returns an independent copy of the map. Changes to either map are not
+// seen by the other.
+//
+// The complexity is O(1).
+func (m *btreeMap[K, V]) Clone() *btreeMap[K, V] {
+	return &btreeMap[K, V]{
+		tree:       m.tree.Clone(),
+		duplicates: m.duplicates,
+	}
+}
+
+// findFirst returns the node and element index of the first element equal to
+// value in order, or a nil node when the value does not exist.
+func (bt *btree[K]) findFirst(n *node[K], value K) (*node[K], int) {
+	if n == nil {
+		return nil, 0
+	}
+	i, found := n.search(value, bt.cmp)
+	// Equal elements may also exist in the subtree to the left of i.
+	if len(n.children) != 0 {
+		if fn, fi := bt.findFirst(n.children[i], value); fn != nil {
+			return fn, fi
+		}
+	}
+	if found {
+		return n, i
+	}
+	return nil, 0
+}
+
+// eachEqual calls fn in order for every element equal to value.
+func (bt *btree[K]) eachEqual(n *node[K], value K, fn func(K)) {
+	if n == nil {
+		return
+	}
+	i, _ := n.search(value, bt.cmp)
+	for ; i <= len(n.elements); i++ {
+		if len(n.children) != 0 {
+			bt.eachEqual(n.children[i], value, fn)
+		}
+		if i == len(n.elements) || bt.cmp(n.elements[i], value) != 0 {
+			return
+		}
+		fn(n.elements[i])
+	}
+}
+
+// replaceFirst replaces the first element equal to value in order with value.
+// Only the nodes on the path to the element are copied when they are not owned
+// by the tree.
+//
+// Returns the node to use in place of n and whether an element was replaced.
+func (bt *btree[K]) replaceFirst(n *node[K], value K) (*node[K], bool) {
+	if n == nil {
+		return nil, false
+	}
+	i, found := n.search(value, bt.cmp)
+	// Equal elements may also exist in the subtree to the left of i.
+	if len(n.children) != 0 {
+		if c, ok := bt.replaceFirst(n.children[i], value); ok {
+			n = bt.mutable(n)
+			n.children[i] = c
+			return n, true
+		}
+	}
+	if found {
+		n = bt.mutable(n)
+		n.elements[i] = value
+		return n, true
+	}
+	return n, false
+}
@@ -0,0 +1,137 @@
+package btree
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMapPutGet(t *testing.T) {
+	m, _ := NewOrderedMap[int, string](3, Replace)
+	for i := 20; 0 < i; i-- {
+		m.Put(i, fmt.Sprint(i))
+	}
+	for i := 1; i <= 20; i++ {
+		v, ok := m.Get(i)
+		if !ok {
+			t.Fatalf("expected %v to exist", i)
+		}
+		if v != fmt.Sprint(i) {
+			t.Errorf("expected value for %v to be %v got %v", i, i, v)
+		}
+	}
+	if _, ok := m.Get(21); ok {
+		t.Error("did not expect 21 to exist")
+	}
+}
+
+func TestMapDuplicates(t *testing.T) {
+	t.Run("replace", func(t *testing.T) {
+		m, _ := NewOrderedMap[string, int](3, Replace)
+		m.Put("a", 1)
+		if !m.Put("a", 2) {
+			t.Error("expected put to replace")
+		}
+		checkMapGet(t, m, "a", 2)
+		checkMapGetAll(t, m, "a", 2)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		m, _ := NewOrderedMap[string, int](3, Reject)
+		m.Put("a", 1)
+		if m.Put("a", 2) {
+			t.Error("expected put to be rejected")
+		}
+		checkMapGet(t, m, "a", 1)
+		checkMapGetAll(t, m, "a", 1)
+	})
+
+	t.Run("multi", func(t *testing.T) {
+		m, _ := NewOrderedMap[string, int](3, Multi)
+		m.Put("b", 0)
+		for i := 1; i <= 10; i++ {
+			m.Put("a", i)
+			m.Put("c", -i)
+		}
+		checkMapGet(t, m, "a", 1)
+		checkMapGetAll(t, m, "a", 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		checkMapGetAll(t, m, "b", 0)
+	})
+}
+
+func TestMapDelete(t *testing.T) {
+	t.Run("single", func(t *testing.T) {
+		m, _ := NewOrderedMap[int, int](3, Replace)
+		for i := 0; i < 10; i++ {
+			m.Put(i, i*10)
+		}
+		if !m.Delete(4) {
+			t.Error("expected 4 to be deleted")
+		}
+		if _, ok := m.Get(4); ok {
+			t.Error("did not expect 4 to exist")
+		}
+		checkMapGet(t, m, 5, 50)
+		if m.Delete(4) {
+			t.Error("did not expect 4 to be deleted twice")
+		}
+	})
+
+	t.Run("multi", func(t *testing.T) {
+		m, _ := NewOrderedMap[int, int](3, Multi)
+		for i := 0; i < 10; i++ {
+			m.Put(i%3, i)
+		}
+		if !m.Delete(1) {
+			t.Error("expected 1 to be deleted")
+		}
+		checkMapGetAll(t, m, 1)
+		checkMapGetAll(t, m, 0, 0, 3, 6, 9)
+		checkMapGetAll(t, m, 2, 2, 5, 8)
+	})
+}
+
+func TestMapClone(t *testing.T) {
+	m, _ := NewOrderedMap[int, string](3, Replace)
+	for i := 0; i < 10; i++ {
+		m.Put(i, fmt.Sprint(i))
+	}
+	clone := m.Clone()
+	clone.Put(3, "three")
+	clone.Delete(4)
+	checkMapGet(t, m, 3, "3")
+	checkMapGet(t, m, 4, "4")
+	checkMapGet(t, clone, 3, "three")
+	if _, ok := clone.Get(4); ok {
+		t.Error("did not expect 4 to exist in the clone")
+	}
+}
+
+func TestMapNilComparator(t *testing.T) {
+	if _, err := NewMap[int, int](3, nil, Replace); err == nil {
+		t.Error("expected an error for a nil comparator")
+	}
+}
+
+func checkMapGet[K, V comparable](t *testing.T, m *btreeMap[K, V], key K, want V) {
+	t.Helper()
+	got, ok := m.Get(key)
+	if !ok {
+		t.Fatalf("expected %v to exist", key)
+	}
+	if got != want {
+		t.Errorf("expected value for %v to be %v got %v", key, want, got)
+	}
+}
+
+func checkMapGetAll[K, V comparable](t *testing.T, m *btreeMap[K, V], key K, want ...V) {
+	t.Helper()
+	got := m.GetAll(key)
+	if len(got) != len(want) {
+		t.Fatalf("expected values for %v to be %v got %v", key, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected values for %v to be %v got %v", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package btree
+
+// Min returns the least element in the tree.
+//
+// Returns false when the tree is empty.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Min() (K, bool) {
+	if bt.root == nil {
+		var zero K
+		return zero, false
+	}
+	n := bt.root
+	for len(n.children) != 0 {
+		n = n.children[0]
+	}
+	return n.elements[0], true
+}
+
+// Max returns the greatest element in the tree.
+//
+// Returns false when the tree is empty.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Max() (K, bool) {
+	if bt.root == nil {
+		var zero K
+		return zero, false
+	}
+	n := bt.root
+	for len(n.children) != 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n.elements[len(n.elements)-1], true
+}
+
+// Floor returns the greatest element less than or equal to the given value.
+//
+// Returns false when no such element exists.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Floor(value K) (K, bool) {
+	return bt.before(func(n *node[K]) int {
+		return n.searchAfter(value, bt.cmp)
+	})
+}
+
+// Ceiling returns the least element greater than or equal to the given value.
+//
+// Returns false when no such element exists.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Ceiling(value K) (K, bool) {
+	return bt.after(func(n *node[K]) int {
+		i, _ := n.search(value, bt.cmp)
+		return i
+	})
+}
+
+// Predecessor returns the greatest element less than the given value.
+//
+// Returns false when no such element exists.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Predecessor(value K) (K, bool) {
+	return bt.before(func(n *node[K]) int {
+		i, _ := n.search(value, bt.cmp)
+		return i
+	})
+}
+
+// Successor returns the least element greater than the given value.
+//
+// Returns false when no such element exists.
+//
+// The complexity is O(log n).
+func (bt *btree[K]) Successor(value K) (K, bool) {
+	return bt.after(func(n *node[K]) int {
+		return n.searchAfter(value, bt.cmp)
+	})
+}
+
+// before descends the tree looking for the greatest element before the index
+// returned by bound. bound splits the elements of a node into the elements that
+// qualify, before the index, and the ones that do not. The child at the index
+// holds elements greater than the best candidate of the node, so it is searched
+// next for a better candidate.
+func (bt *btree[K]) before(bound func(n *node[K]) int) (K, bool) {
+	var best K
+	found := false
+	for n := bt.root; n != nil; {
+		i := bound(n)
+		if 0 < i {
+			best, found = n.elements[i-1], true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return best, found
+}
+
+// after descends the tree looking for the least element at or after the index
+// returned by bound. It is the mirror of before.
+func (bt *btree[K]) after(bound func(n *node[K]) int) (K, bool) {
+	var best K
+	found := false
+	for n := bt.root; n != nil; {
+		i := bound(n)
+		if i < len(n.elements) {
+			best, found = n.elements[i], true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return best, found
+}
@@ -0,0 +1,90 @@
+package btree
+
+import (
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	t.Run("populated tree", func(t *testing.T) {
+		for degree := 3; degree <= 7; degree++ {
+			bt, _ := NewOrdered(degree, 5, 3, 9, 1, 7, 8, 2, 6, 4, 0, 11, 10)
+			checkNearest(t, "min", bt.Min, 0)
+			checkNearest(t, "max", bt.Max, 11)
+		}
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		bt, _ := NewOrdered[int](3)
+		checkNearestMissing(t, "min", bt.Min)
+		checkNearestMissing(t, "max", bt.Max)
+	})
+}
+
+func TestNearest(t *testing.T) {
+	for degree := 3; degree <= 7; degree++ {
+		values := []int{}
+		for i := 0; i < 50; i++ {
+			values = append(values, ((i*13)%50)*10)
+		}
+		bt, _ := NewOrdered(degree, values...)
+		for i := 0; i < 50; i++ {
+			v := i * 10
+			checkNearestValue(t, "floor", bt.Floor, v, v)
+			checkNearestValue(t, "floor", bt.Floor, v+5, v)
+			checkNearestValue(t, "ceiling", bt.Ceiling, v, v)
+			checkNearestValue(t, "ceiling", bt.Ceiling, v-5, v)
+			checkNearestValue(t, "predecessor", bt.Predecessor, v+1, v)
+			checkNearestValue(t, "predecessor", bt.Predecessor, v+10, v)
+			checkNearestValue(t, "successor", bt.Successor, v-1, v)
+			checkNearestValue(t, "successor", bt.Successor, v-10, v)
+		}
+		checkNearestValueMissing(t, "floor", bt.Floor, -1)
+		checkNearestValueMissing(t, "ceiling", bt.Ceiling, 491)
+		checkNearestValueMissing(t, "predecessor", bt.Predecessor, 0)
+		checkNearestValueMissing(t, "successor", bt.Successor, 490)
+	}
+
+	t.Run("duplicates", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 2, 2, 2, 2, 3)
+		checkNearestValue(t, "predecessor", bt.Predecessor, 2, 1)
+		checkNearestValue(t, "successor", bt.Successor, 2, 3)
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		bt, _ := NewOrdered[int](3)
+		checkNearestValueMissing(t, "floor", bt.Floor, 1)
+		checkNearestValueMissing(t, "ceiling", bt.Ceiling, 1)
+		checkNearestValueMissing(t, "predecessor", bt.Predecessor, 1)
+		checkNearestValueMissing(t, "successor", bt.Successor, 1)
+	})
+}
+
+func checkNearest(t *testing.T, name string, f func() (int, bool), want int) {
+	t.Helper()
+	got, ok := f()
+	if !ok || got != want {
+		t.Errorf("expected %v to be %v got %v", name, want, got)
+	}
+}
+
+func checkNearestMissing(t *testing.T, name string, f func() (int, bool)) {
+	t.Helper()
+	if got, ok := f(); ok {
+		t.Errorf("did not expect %v got %v", name, got)
+	}
+}
+
+func checkNearestValue(t *testing.T, name string, f func(int) (int, bool), value, want int) {
+	t.Helper()
+	got, ok := f(value)
+	if !ok || got != want {
+		t.Errorf("expected %v of %v to be %v got %v", name, value, want, got)
+	}
+}
+
+func checkNearestValueMissing(t *testing.T, name string, f func(int) (int, bool), value int) {
+	t.Helper()
+	if got, ok := f(value); ok {
+		t.Errorf("did not expect %v of %v got %v", name, value, got)
+	}
+}
@@ -0,0 +1,226 @@
+package btree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/chirst/al-go-rithms/list"
+)
+
+// pageID is the index of a page in a file. The byte offset of a page is its id
+// times the page size.
+type pageID uint32
+
+// frame is a page held in the buffer pool.
+type frame struct {
+	id   pageID
+	data []byte
+	// dirty is true when data has changes that are not written to the file
+	// yet.
+	dirty bool
+}
+
+// pager reads and writes the fixed size pages of a file through a buffer pool.
+//
+// The buffer pool holds at most capacity pages. When it is full the least
+// recently used page is evicted to make room, which writes the page to the WAL
+// first when it is dirty. Pages are otherwise only written by flush. Pages only
+// reach the data file through the WAL, so a crash at any point leaves the file
+// as it was after a flush.
+type pager struct {
+	data     storage
+	wal      storage
+	pageSize int
+	capacity int
+	// pages is the amount of pages in the file, including pages that are
+	// allocated but only exist in the buffer pool so far.
+	pages pageID
+	// lru orders the frames in the buffer pool from most to least recently
+	// used.
+	lru *list.List[*frame]
+	// frames finds the element of lru holding a page.
+	frames map[pageID]*list.Element[*frame]
+	// walIndex finds the offset of the latest image of a page in the WAL.
+	walIndex map[pageID]int64
+	// walSize is the amount of bytes in the WAL.
+	walSize int64
+	// salt is mixed into the checksum of every frame in the WAL.
+	salt uint32
+	// reads and writes count the pages read from the files and written to
+	// the WAL.
+	reads, writes int
+}
+
+// openPager opens the file at path for reading and writing pages of pageSize
+// bytes with a buffer pool of capacity pages. The file and its WAL are created
+// when they do not exist, otherwise the file is recovered from the WAL.
+func openPager(path string, pageSize, capacity int) (*pager, error) {
+	if pageSize <= 0 {
+		return nil, errors.New("page size must be greater than 0")
+	}
+	if capacity <= 0 {
+		return nil, errors.New("buffer pool must hold at least 1 page")
+	}
+	data, err := openStorage(path)
+	if err != nil {
+		return nil, err
+	}
+	wal, err := openStorage(walPath(path))
+	if err != nil {
+		data.Close()
+		return nil, err
+	}
+	p := &pager{
+		data:     data,
+		wal:      wal,
+		pageSize: pageSize,
+		capacity: capacity,
+		lru:      list.New[*frame](),
+		frames:   map[pageID]*list.Element[*frame]{},
+		walIndex: map[pageID]int64{},
+		salt:     newSalt(0),
+	}
+	if err := p.open(); err != nil {
+		p.closeFiles()
+		return nil, err
+	}
+	return p, nil
+}
+
+// open recovers the file and counts its pages.
+func (p *pager) open() error {
+	if err := p.recoverWAL(); err != nil {
+		return err
+	}
+	info, err := p.data.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size()%int64(p.pageSize) != 0 {
+		return fmt.Errorf("file size %v is not a multiple of the page size %v", info.Size(), p.pageSize)
+	}
+	p.pages = max(p.pages, pageID(info.Size()/int64(p.pageSize)))
+	return nil
+}
+
+// get returns the frame holding the page with the given id, reading the page
+// from the file when it is not in the buffer pool.
+//
+// The frame is only guaranteed to stay in the buffer pool until the next call
+// to the pager. Callers that change data must set dirty.
+func (p *pager) get(id pageID) (*frame, error) {
+	if p.pages <= id {
+		return nil, fmt.Errorf("page %v does not exist, there are %v pages", id, p.pages)
+	}
+	if e, ok := p.frames[id]; ok {
+		p.lru.MoveToFront(e)
+		return e.Value(), nil
+	}
+	f, err := p.newFrame(id)
+	if err != nil {
+		return nil, err
+	}
+	// The latest image of a page evicted since the last flush is in the WAL.
+	var n int
+	if offset, ok := p.walIndex[id]; ok {
+		n, err = p.wal.ReadAt(f.data, offset)
+	} else {
+		n, err = p.data.ReadAt(f.data, p.offset(id))
+	}
+	// A page allocated after the file was last extended may not be in the
+	// file yet if it was never evicted, it reads as zeroes.
+	if err != nil && !(errors.Is(err, io.EOF) && n == 0) {
+		p.drop(id)
+		return nil, err
+	}
+	p.reads++
+	return f, nil
+}
+
+// allocate adds a zeroed page to the end of the file and returns its frame.
+// The frame is dirty so the page is written by the next flush.
+func (p *pager) allocate() (*frame, error) {
+	f, err := p.newFrame(p.pages)
+	if err != nil {
+		return nil, err
+	}
+	f.dirty = true
+	p.pages++
+	return f, nil
+}
+
+// newFrame adds an empty frame for the page to the buffer pool, evicting the
+// least recently used page when the buffer pool is full.
+func (p *pager) newFrame(id pageID) (*frame, error) {
+	if p.capacity <= p.lru.Len() {
+		if err := p.evict(); err != nil {
+			return nil, err
+		}
+	}
+	f := &frame{
+		id:   id,
+		data: make([]byte, p.pageSize),
+	}
+	p.frames[id] = p.lru.Prepend(f)
+	return f, nil
+}
+
+// evict removes the least recently used page from the buffer pool, writing it
+// to the WAL first when it is dirty.
+func (p *pager) evict() error {
+	f := p.lru.Back().Value()
+	if err := p.writeFrame(f); err != nil {
+		return err
+	}
+	p.drop(f.id)
+	return nil
+}
+
+// drop removes a page from the buffer pool without writing it.
+func (p *pager) drop(id pageID) {
+	p.lru.RemoveElement(p.frames[id])
+	delete(p.frames, id)
+}
+
+// writeFrame writes the frame to the WAL when it is dirty. The write is not
+// committed until the next flush.
+func (p *pager) writeFrame(f *frame) error {
+	if !f.dirty {
+		return nil
+	}
+	if err := p.appendFrame(f.id, f.data, 0); err != nil {
+		return err
+	}
+	f.dirty = false
+	return nil
+}
+
+// flush commits every change since the last flush to the WAL and then
+// checkpoints it to the file, so every change is in stable storage once flush
+// returns.
+func (p *pager) flush() error {
+	if err := p.commit(); err != nil {
+		return err
+	}
+	return p.checkpoint()
+}
+
+// close flushes the pager and closes the file.
+func (p *pager) close() error {
+	if err := p.flush(); err != nil {
+		p.closeFiles()
+		return err
+	}
+	return p.closeFiles()
+}
+
+// closeFiles closes the file and the WAL without flushing.
+func (p *pager) closeFiles() error {
+	return errors.Join(p.data.Close(), p.wal.Close())
+}
+
+// offset returns the byte offset of a page in the file.
+func (p *pager) offset(id pageID) int64 {
+	return int64(id) * int64(p.pageSize)
+}
@@ -0,0 +1,120 @@
+package btree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPagerLRU(t *testing.T) {
+	p, err := openPager(filepath.Join(t.TempDir(), "pages"), 16, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.close()
+	for i := 0; i < 3; i++ {
+		f, err := p.allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.data[0] = byte(i + 1)
+	}
+	// Allocating the third page evicted the first, which was dirty.
+	if _, ok := p.frames[0]; ok {
+		t.Error("expected page 0 to be evicted")
+	}
+	if p.writes != 1 {
+		t.Errorf("expected 1 write got %v", p.writes)
+	}
+
+	// Reading page 0 back evicts page 1, the least recently used page now.
+	f, err := p.get(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.data[0] != 1 {
+		t.Errorf("expected page 0 to hold 1 got %v", f.data[0])
+	}
+	if _, ok := p.frames[1]; ok {
+		t.Error("expected page 1 to be evicted")
+	}
+	if p.reads != 1 || p.writes != 2 {
+		t.Errorf("expected 1 read and 2 writes got %v and %v", p.reads, p.writes)
+	}
+
+	// A hit moves the page to the front so page 0 is evicted next, not page 2.
+	if _, err := p.get(2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.get(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.frames[2]; !ok {
+		t.Error("expected page 2 to stay in the buffer pool")
+	}
+	if p.reads != 2 {
+		t.Errorf("expected 2 reads got %v", p.reads)
+	}
+}
+
+func TestPagerFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages")
+	p, err := openPager(path, 16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		f, _ := p.allocate()
+		f.data[0] = byte(i + 1)
+	}
+	if err := p.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if p.writes != 3 {
+		t.Errorf("expected 3 writes got %v", p.writes)
+	}
+	// Clean pages are not written again.
+	f, _ := p.get(1)
+	f.data[0] = 5
+	f.dirty = true
+	if err := p.close(); err != nil {
+		t.Fatal(err)
+	}
+	if p.writes != 4 {
+		t.Errorf("expected 4 writes got %v", p.writes)
+	}
+
+	p, err = openPager(path, 16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.close()
+	if p.pages != 3 {
+		t.Fatalf("expected 3 pages got %v", p.pages)
+	}
+	for i, want := range []byte{1, 5, 3} {
+		f, err := p.get(pageID(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.data[0] != want {
+			t.Errorf("expected page %v to hold %v got %v", i, want, f.data[0])
+		}
+	}
+	if _, err := p.get(3); err == nil {
+		t.Error("expected an error for a page past the end of the file")
+	}
+}
+
+func TestOpenPagerErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages")
+	if err := os.WriteFile(path, make([]byte, 20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openPager(path, 16, 4); err == nil {
+		t.Error("expected an error for a file that is not a multiple of the page size")
+	}
+	if _, err := openPager(path, 20, 0); err == nil {
+		t.Error("expected an error for an empty buffer pool")
+	}
+}
@@ -0,0 +1,67 @@
+package btree
+
+// Len returns the count of elements in the tree.
+//
+// The complexity is O(1).
+func (bt *btree[K]) Len() int {
+	if bt.root == nil {
+		return 0
+	}
+	return bt.root.count
+}
+
+// Rank returns the amount of elements in the tree that are less than the given
+// value. When the value exists Rank is the zero based index of its first
+// occurrence in order.
+//
+// The complexity is O(degree * log n) since the counts of the children left of
+// the path are summed at every level. Counts are not kept as prefix sums so
+// inserts and deletes only update a single count per level.
+func (bt *btree[K]) Rank(value K) int {
+	rank := 0
+	n := bt.root
+	for n != nil {
+		// Every element before i is less than value.
+		i, _ := n.search(value, bt.cmp)
+		rank += i
+		if len(n.children) == 0 {
+			break
+		}
+		// Every child before i only holds elements less than value.
+		for _, c := range n.children[:i] {
+			rank += c.count
+		}
+		n = n.children[i]
+	}
+	return rank
+}
+
+// Select returns the element at the zero based index k of the tree in order.
+//
+// Returns false when k is not in the set of indexes.
+//
+// The complexity is O(degree * log n). Child counts are walked at every level
+// the same as in Rank.
+func (bt *btree[K]) Select(k int) (K, bool) {
+	if k < 0 || bt.Len() <= k {
+		var zero K
+		return zero, false
+	}
+	n := bt.root
+	for len(n.children) != 0 {
+		next := n.children[len(n.children)-1]
+		for i, c := range n.children[:len(n.elements)] {
+			if k < c.count {
+				next = c
+				break
+			}
+			k -= c.count
+			if k == 0 {
+				return n.elements[i], true
+			}
+			k--
+		}
+		n = next
+	}
+	return n.elements[k], true
+}
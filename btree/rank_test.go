@@ -0,0 +1,95 @@
+package btree
+
+import (
+	"testing"
+)
+
+func TestLen(t *testing.T) {
+	bt, _ := NewOrdered[int](3)
+	checkTreeLen(t, bt, 0)
+	for i := 0; i < 20; i++ {
+		bt.Insert(i % 5)
+		checkTreeLen(t, bt, i+1)
+	}
+	for i := 0; i < 20; i++ {
+		bt.Delete(i % 5)
+		checkTreeLen(t, bt, 19-i)
+	}
+	bt.Delete(0)
+	checkTreeLen(t, bt, 0)
+}
+
+func TestRank(t *testing.T) {
+	for degree := 3; degree <= 7; degree++ {
+		values := []int{}
+		for i := 0; i < 100; i++ {
+			values = append(values, ((i*37)%100)*2)
+		}
+		bt, _ := NewOrdered(degree, values...)
+		for i := 0; i < 100; i++ {
+			if r := bt.Rank(i * 2); r != i {
+				t.Fatalf("degree %v expected rank of %v to be %v got %v", degree, i*2, i, r)
+			}
+			if r := bt.Rank(i*2 + 1); r != i+1 {
+				t.Fatalf("degree %v expected rank of %v to be %v got %v", degree, i*2+1, i+1, r)
+			}
+		}
+		if r := bt.Rank(-1); r != 0 {
+			t.Errorf("expected rank of -1 to be 0 got %v", r)
+		}
+	}
+
+	t.Run("duplicates", func(t *testing.T) {
+		bt, _ := NewOrdered(3, 1, 2, 2, 2, 2, 2, 3)
+		if r := bt.Rank(2); r != 1 {
+			t.Errorf("expected rank of 2 to be 1 got %v", r)
+		}
+		if r := bt.Rank(3); r != 6 {
+			t.Errorf("expected rank of 3 to be 6 got %v", r)
+		}
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		bt, _ := NewOrdered[int](3)
+		if r := bt.Rank(1); r != 0 {
+			t.Errorf("expected rank of 1 to be 0 got %v", r)
+		}
+	})
+}
+
+func TestSelect(t *testing.T) {
+	for degree := 3; degree <= 7; degree++ {
+		values := []int{}
+		for i := 0; i < 100; i++ {
+			values = append(values, (i*37)%100)
+		}
+		bt, _ := NewOrdered(degree, values...)
+		for i := 0; i < 100; i += 3 {
+			bt.Delete(i)
+		}
+		want := []int{}
+		bt.AllFunc(func(e int) bool {
+			want = append(want, e)
+			return true
+		})
+		for k, w := range want {
+			got, ok := bt.Select(k)
+			if !ok || got != w {
+				t.Fatalf("degree %v expected select %v to be %v got %v", degree, k, w, got)
+			}
+		}
+		if _, ok := bt.Select(len(want)); ok {
+			t.Errorf("did not expect select %v to exist", len(want))
+		}
+		if _, ok := bt.Select(-1); ok {
+			t.Error("did not expect select -1 to exist")
+		}
+	}
+}
+
+func checkTreeLen(t *testing.T, bt *btree[int], want int) {
+	t.Helper()
+	if got := bt.Len(); got != want {
+		t.Errorf("expected len to be %v got %v", want, got)
+	}
+}
@@ -0,0 +1,153 @@
+package btree
+
+import (
+	"fmt"
+)
+
+// Validate checks the structure of the tree and returns an error describing the
+// first violation found, or nil when the tree is valid.
+//
+// The following are checked for every node:
+//   - Elements are ordered and fall between the elements of the parent that
+//     surround the node.
+//   - Internal nodes have one more child than elements.
+//   - Nodes have fewer elements than the degree and every node other than the
+//     root has at least the minimum amount of elements.
+//   - Every leaf is at the same depth.
+//   - The subtree count matches the elements in the subtree.
+//
+// The error describes the path from the root to the invalid node, for example
+// root.children[1].children[0].
+//
+// The complexity is O(n).
+func (bt *btree[K]) Validate() error {
+	if bt.root == nil {
+		return nil
+	}
+	v := validator[K]{
+		nodeChecker: newNodeChecker(bt.degree, bt.cmp),
+	}
+	return v.validate(bt.root, "root", nil, nil, 0)
+}
+
+// validator holds the state shared while validating a tree.
+type validator[K any] struct {
+	nodeChecker[K]
+}
+
+// validate recursively checks the subtree of n. lo and hi are the elements of
+// the parent surrounding n, nil when there is no element on that side.
+func (v *validator[K]) validate(n *node[K], path string, lo, hi *K, depth int) error {
+	if err := v.check(path, n.elements, len(n.children), lo, hi, depth); err != nil {
+		return err
+	}
+	count := len(n.elements)
+	for i, c := range n.children {
+		childLo, childHi := childBounds(n.elements, i, lo, hi)
+		childPath := fmt.Sprintf("%v.children[%v]", path, i)
+		if err := v.validate(c, childPath, childLo, childHi, depth+1); err != nil {
+			return err
+		}
+		count += c.count
+	}
+	if n.count != count {
+		return fmt.Errorf("%v: node has a count of %v, the subtree has %v", path, n.count, count)
+	}
+	return nil
+}
+
+// nodeChecker checks the rules every tree in the package shares one node at a
+// time. Each tree walks its own nodes with it and checks the rules specific to
+// the tree, such as counts or leaf links, itself.
+type nodeChecker[K any] struct {
+	degree int
+	cmp    func(a, b K) int
+	// leafDepth is the depth of the first leaf found or -1 before any leaf is
+	// found.
+	leafDepth int
+}
+
+// newNodeChecker returns a nodeChecker for a tree with the given degree and
+// comparator that has not found any leaf yet.
+func newNodeChecker[K any](degree int, cmp func(a, b K) int) nodeChecker[K] {
+	return nodeChecker[K]{
+		degree:    degree,
+		cmp:       cmp,
+		leafDepth: -1,
+	}
+}
+
+// check checks a node holding elements with the given amount of children at
+// depth, where the root is at depth 0. path describes the node in the error.
+// lo and hi are the elements of the parent surrounding the node, nil when
+// there is no element on that side.
+func (c *nodeChecker[K]) check(path string, elements []K, children int, lo, hi *K, depth int) error {
+	if len(elements) == 0 {
+		return fmt.Errorf("%v: node has no elements", path)
+	}
+	if c.degree <= len(elements) {
+		return fmt.Errorf(
+			"%v: node has %v elements, the degree is %v",
+			path,
+			len(elements),
+			c.degree,
+		)
+	}
+	if 0 < depth && len(elements) < minElements(c.degree) {
+		return fmt.Errorf(
+			"%v: node has %v elements, the minimum is %v",
+			path,
+			len(elements),
+			minElements(c.degree),
+		)
+	}
+
+	for i, e := range elements {
+		if 0 < i && 0 < c.cmp(elements[i-1], e) {
+			return fmt.Errorf("%v: element %v is less than the element before it", path, e)
+		}
+		if lo != nil && c.cmp(e, *lo) < 0 {
+			return fmt.Errorf("%v: element %v is less than parent element %v", path, e, *lo)
+		}
+		if hi != nil && 0 < c.cmp(e, *hi) {
+			return fmt.Errorf("%v: element %v is greater than parent element %v", path, e, *hi)
+		}
+	}
+
+	if children == 0 {
+		if c.leafDepth == -1 {
+			c.leafDepth = depth
+		}
+		if c.leafDepth != depth {
+			return fmt.Errorf(
+				"%v: leaf is at depth %v, other leaves are at depth %v",
+				path,
+				depth,
+				c.leafDepth,
+			)
+		}
+		return nil
+	}
+	if children != len(elements)+1 {
+		return fmt.Errorf(
+			"%v: node has %v children for %v elements",
+			path,
+			children,
+			len(elements),
+		)
+	}
+	return nil
+}
+
+// childBounds returns the elements surrounding child i of a node with the given
+// elements. The first and last child take lo and hi, the elements surrounding
+// the node itself.
+func childBounds[K any](elements []K, i int, lo, hi *K) (*K, *K) {
+	if 0 < i {
+		lo = &elements[i-1]
+	}
+	if i < len(elements) {
+		hi = &elements[i]
+	}
+	return lo, hi
+}
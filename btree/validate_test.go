@@ -0,0 +1,91 @@
+package btree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	// newTree returns a degree 3 tree shaped as:
+	//
+	//	        4
+	//	    2       6
+	//	  1   3   5   7
+	newTree := func() *btree[int] {
+		bt, _ := NewOrdered(3, 1, 2, 3, 4, 5, 6, 7)
+		return bt
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := newTree().Validate(); err != nil {
+			t.Error(err)
+		}
+		empty, _ := NewOrdered[int](3)
+		if err := empty.Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("unordered elements", func(t *testing.T) {
+		bt, _ := NewOrdered(5, 1, 2, 3)
+		bt.root.elements[0], bt.root.elements[1] = 2, 1
+		checkValidateError(t, bt, "root: element 1 is less than the element before it")
+	})
+
+	t.Run("element outside parent bounds", func(t *testing.T) {
+		bt := newTree()
+		bt.root.children[1].children[0].elements[0] = 3
+		checkValidateError(t, bt, "root.children[1].children[0]: element 3 is less than parent element 4")
+	})
+
+	t.Run("mis-ordered children", func(t *testing.T) {
+		bt := newTree()
+		c := bt.root.children[0].children
+		c[0], c[1] = c[1], c[0]
+		checkValidateError(t, bt, "root.children[0].children[0]: element 3 is greater than parent element 2")
+	})
+
+	t.Run("children count", func(t *testing.T) {
+		bt := newTree()
+		bt.root.children[1].children = bt.root.children[1].children[:1]
+		checkValidateError(t, bt, "root.children[1]: node has 1 children for 1 elements")
+	})
+
+	t.Run("too many elements", func(t *testing.T) {
+		bt := newTree()
+		leaf := bt.root.children[1].children[1]
+		leaf.elements = append(leaf.elements, 8, 9)
+		checkValidateError(t, bt, "root.children[1].children[1]: node has 3 elements, the degree is 3")
+	})
+
+	t.Run("too few elements", func(t *testing.T) {
+		bt, _ := NewOrdered(5, 1, 2, 3, 4, 5)
+		bt.root.children[1].elements = bt.root.children[1].elements[:1]
+		checkValidateError(t, bt, "root.children[1]: node has 1 elements, the minimum is 2")
+	})
+
+	t.Run("leaf depth", func(t *testing.T) {
+		bt := newTree()
+		right := bt.root.children[1]
+		right.children = nil
+		right.count = 1
+		checkValidateError(t, bt, "root.children[1]: leaf is at depth 1, other leaves are at depth 2")
+	})
+
+	t.Run("count", func(t *testing.T) {
+		bt := newTree()
+		bt.root.children[0].count++
+		checkValidateError(t, bt, "root.children[0]: node has a count of 4, the subtree has 3")
+	})
+}
+
+func checkValidateError(t *testing.T, bt interface{ Validate() error }, want string) {
+	t.Helper()
+	err := bt.Validate()
+	if err == nil {
+		t.Fatalf("expected error %q", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error %q got %q", want, err)
+	}
+}
@@ -0,0 +1,249 @@
+package btree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/rand"
+	"os"
+)
+
+// storage is a file the pager reads and writes.
+type storage interface {
+	io.ReaderAt
+	io.WriterAt
+	Sync() error
+	Truncate(size int64) error
+	Stat() (os.FileInfo, error)
+	Close() error
+}
+
+// openStorage opens the file at path for reading and writing, creating it when
+// it does not exist. Tests replace it to inject faults.
+var openStorage = func(path string) (storage, error) {
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+}
+
+// The write ahead log, or WAL, is a file next to the data file that every
+// page is written to before it is written to the data file. This way the data
+// file only ever changes by copying in pages that are safely in the WAL.
+//
+// The WAL starts with a header holding the walMagic, the page size and a salt.
+// Each frame after the header is a page image prefixed by the page id, a commit
+// field and a checksum of the frame and the salt. The salt changes every time
+// the WAL is emptied, so frames left over from before can never be mistaken
+// for frames of the current WAL. The commit field is 0 for every frame but
+// the last frame of a flush, where it holds the amount of pages in the file
+// after the flush. The frames up to and including a commit frame are a
+// committed transaction.
+//
+// A flush appends every dirty page to the WAL, ending with a commit frame, and
+// syncs the WAL. Then it checkpoints, which copies the latest image of every
+// page in the WAL to the data file, syncs the data file and empties the WAL.
+//
+// Recovery after a crash checkpoints every committed transaction in the WAL and
+// discards the frames after the last commit frame. A frame with a checksum that
+// does not match, such as a frame that was only partly written, ends the WAL.
+const (
+	// walMagic identifies a WAL file.
+	walMagic = "BTWL"
+	// walHeaderSize is the amount of bytes before the first frame.
+	walHeaderSize = 12
+	// frameHeaderSize is the amount of bytes before the page image of a
+	// frame.
+	frameHeaderSize = 12
+)
+
+// walPath returns the path of the WAL for the data file at path.
+func walPath(path string) string {
+	return path + "-wal"
+}
+
+// Recover makes the disk tree stored in the file at path consistent after a
+// crash. Transactions that were committed to the WAL are written to the file
+// and partial transactions are discarded. pageSize must be the page size the
+// file was created with.
+//
+// OpenDisk recovers the file on open, so Recover only needs to be called to
+// recover a file without opening it.
+func Recover(path string, pageSize int) error {
+	p, err := openPager(path, pageSize, 1)
+	if err != nil {
+		return err
+	}
+	return p.close()
+}
+
+// appendFrame appends a page image to the WAL. commit is the amount of pages
+// in the file when the frame ends a transaction, otherwise it is 0.
+func (p *pager) appendFrame(id pageID, data []byte, commit pageID) error {
+	if p.walSize == 0 {
+		header := make([]byte, walHeaderSize)
+		copy(header, walMagic)
+		binary.LittleEndian.PutUint32(header[4:], uint32(p.pageSize))
+		binary.LittleEndian.PutUint32(header[8:], p.salt)
+		if _, err := p.wal.WriteAt(header, 0); err != nil {
+			return err
+		}
+		p.walSize = walHeaderSize
+	}
+	frame := make([]byte, frameHeaderSize+len(data))
+	binary.LittleEndian.PutUint32(frame[0:], uint32(id))
+	binary.LittleEndian.PutUint32(frame[4:], uint32(commit))
+	copy(frame[frameHeaderSize:], data)
+	binary.LittleEndian.PutUint32(frame[8:], frameChecksum(p.salt, frame))
+	if _, err := p.wal.WriteAt(frame, p.walSize); err != nil {
+		return err
+	}
+	p.walIndex[id] = p.walSize + frameHeaderSize
+	p.walSize += int64(len(frame))
+	p.writes++
+	return nil
+}
+
+// frameChecksum returns the checksum of a frame, which covers the salt, the
+// page id, the commit field and the page image.
+func frameChecksum(salt uint32, frame []byte) uint32 {
+	sum := crc32.ChecksumIEEE(binary.LittleEndian.AppendUint32(nil, salt))
+	sum = crc32.Update(sum, crc32.IEEETable, frame[:8])
+	return crc32.Update(sum, crc32.IEEETable, frame[frameHeaderSize:])
+}
+
+// commit appends every dirty page in the buffer pool to the WAL, marks the
+// last one as a commit frame and syncs the WAL.
+func (p *pager) commit() error {
+	dirty := []*frame{}
+	for e := p.lru.Front(); e != nil; e = e.Next() {
+		if e.Value().dirty {
+			dirty = append(dirty, e.Value())
+		}
+	}
+	if len(dirty) == 0 {
+		if len(p.walIndex) == 0 {
+			return nil
+		}
+		// Evicted pages are waiting in the WAL, but there is no dirty page
+		// left to end the transaction with, so one is written again.
+		f, err := p.get(metaPage)
+		if err != nil {
+			return err
+		}
+		dirty = append(dirty, f)
+	}
+	for i, f := range dirty {
+		var commit pageID
+		if i == len(dirty)-1 {
+			commit = p.pages
+		}
+		if err := p.appendFrame(f.id, f.data, commit); err != nil {
+			return err
+		}
+		f.dirty = false
+	}
+	return p.wal.Sync()
+}
+
+// checkpoint copies the latest image of every page in the WAL to the data file
+// and empties the WAL. Every frame in the WAL must be committed.
+func (p *pager) checkpoint() error {
+	if p.walSize == 0 {
+		return nil
+	}
+	data := make([]byte, p.pageSize)
+	for id, offset := range p.walIndex {
+		if _, err := p.wal.ReadAt(data, offset); err != nil {
+			return err
+		}
+		if _, err := p.data.WriteAt(data, p.offset(id)); err != nil {
+			return err
+		}
+	}
+	// The data file must be durable before the WAL is emptied, otherwise a
+	// crash could lose pages that are in neither file.
+	if err := p.data.Sync(); err != nil {
+		return err
+	}
+	return p.discardWAL()
+}
+
+// recoverWAL reads the WAL left by a previous session, keeps the committed
+// transactions and checkpoints them.
+func (p *pager) recoverWAL() error {
+	info, err := p.wal.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	if size == 0 {
+		return nil
+	}
+	header := make([]byte, walHeaderSize)
+	if size < walHeaderSize {
+		return p.discardWAL()
+	}
+	if _, err := p.wal.ReadAt(header, 0); err != nil {
+		return err
+	}
+	if string(header[:4]) != walMagic {
+		// The header itself was only partly written, so no frame can
+		// follow it.
+		return p.discardWAL()
+	}
+	if pageSize := int(binary.LittleEndian.Uint32(header[4:])); pageSize != p.pageSize {
+		return fmt.Errorf("WAL has a page size of %v, not %v", pageSize, p.pageSize)
+	}
+	p.salt = binary.LittleEndian.Uint32(header[8:])
+
+	// pending holds the frames of the transaction being read until its commit
+	// frame is found.
+	pending := map[pageID]int64{}
+	frame := make([]byte, frameHeaderSize+p.pageSize)
+	offset := int64(walHeaderSize)
+	committed := int64(0)
+	for offset+int64(len(frame)) <= size {
+		if _, err := p.wal.ReadAt(frame, offset); err != nil {
+			return err
+		}
+		if binary.LittleEndian.Uint32(frame[8:]) != frameChecksum(p.salt, frame) {
+			break
+		}
+		id := pageID(binary.LittleEndian.Uint32(frame[0:]))
+		pending[id] = offset + frameHeaderSize
+		offset += int64(len(frame))
+		if commit := pageID(binary.LittleEndian.Uint32(frame[4:])); commit != 0 {
+			for id, o := range pending {
+				p.walIndex[id] = o
+			}
+			clear(pending)
+			p.pages = max(p.pages, commit)
+			committed = offset
+		}
+	}
+	if committed == 0 {
+		return p.discardWAL()
+	}
+	p.walSize = committed
+	return p.checkpoint()
+}
+
+// discardWAL empties the WAL and picks a new salt for the frames written
+// after it.
+func (p *pager) discardWAL() error {
+	if err := p.wal.Truncate(0); err != nil {
+		return err
+	}
+	p.walSize = 0
+	clear(p.walIndex)
+	p.salt = newSalt(p.salt)
+	return p.wal.Sync()
+}
+
+// newSalt returns a salt that differs from salt.
+func newSalt(salt uint32) uint32 {
+	for {
+		if next := rand.Uint32(); next != salt {
+			return next
+		}
+	}
+}
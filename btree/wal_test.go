@@ -0,0 +1,276 @@
+package btree
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+var errCrash = errors.New("crashed")
+
+// faults is shared by the files of a faultStorage so a crash stops writes to
+// both the data file and the WAL.
+type faults struct {
+	// budget is the amount of writes, syncs and truncates that succeed before
+	// the crash.
+	budget int
+	// torn makes the write that crashes write the first half of its bytes.
+	torn    bool
+	crashed bool
+}
+
+// reset gives the faults an unlimited budget and clears the crash.
+func (f *faults) reset() {
+	f.budget, f.crashed = math.MaxInt, false
+}
+
+// step uses up one operation of the budget, reporting false once it crashed.
+func (f *faults) step() bool {
+	if f.crashed || f.budget == 0 {
+		f.crashed = true
+		return false
+	}
+	f.budget--
+	return true
+}
+
+// faultStorage is storage that crashes once its faults run out of budget.
+type faultStorage struct {
+	storage
+	faults *faults
+}
+
+func (s *faultStorage) WriteAt(b []byte, off int64) (int, error) {
+	if s.faults.crashed {
+		return 0, errCrash
+	}
+	if !s.faults.step() {
+		if s.faults.torn {
+			n, _ := s.storage.WriteAt(b[:len(b)/2], off)
+			return n, errCrash
+		}
+		return 0, errCrash
+	}
+	return s.storage.WriteAt(b, off)
+}
+
+// Sync only counts towards the budget. The crash is simulated in the process,
+// so writes already reached the operating system and syncing them only slows
+// down the test.
+func (s *faultStorage) Sync() error {
+	if !s.faults.step() {
+		return errCrash
+	}
+	return nil
+}
+
+func (s *faultStorage) Truncate(size int64) error {
+	if !s.faults.step() {
+		return errCrash
+	}
+	return s.storage.Truncate(size)
+}
+
+// injectFaults makes the files opened by the test crash according to f.
+func injectFaults(t *testing.T, f *faults) {
+	t.Helper()
+	open := openStorage
+	openStorage = func(path string) (storage, error) {
+		s, err := open(path)
+		if err != nil {
+			return nil, err
+		}
+		return &faultStorage{storage: s, faults: f}, nil
+	}
+	t.Cleanup(func() { openStorage = open })
+}
+
+// TestRecoverFaults crashes the disk tree at every write, sync and truncate of
+// a flush that splits a leaf, an internal node and the root. After recovery the
+// tree must be valid and hold either everything from before the flush or
+// everything after it.
+func TestRecoverFaults(t *testing.T) {
+	// 52 ascending values make a tree of height 3 where the right most path
+	// is full, so inserting 52 splits every node on the path.
+	before := []int64{}
+	for i := int64(0); i < 52; i++ {
+		before = append(before, i)
+	}
+	after := slices.Clone(before)
+	for i := int64(52); i < 60; i++ {
+		after = append(after, i)
+	}
+	base := filepath.Join(t.TempDir(), "base")
+	dt := openTestDisk(t, base)
+	if err := insertAll(dt, before); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, torn := range []bool{false, true} {
+		t.Run(fmt.Sprintf("torn %v", torn), func(t *testing.T) {
+			f := &faults{torn: torn}
+			injectFaults(t, f)
+			committed := false
+			for step := 0; ; step++ {
+				path := filepath.Join(t.TempDir(), "tree")
+				if err := os.WriteFile(path, data, 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				f.reset()
+				dt := openTestDisk(t, path)
+				f.budget = step
+				err := insertAll(dt, after[len(before):])
+				if err == nil {
+					err = dt.Flush()
+				}
+				if err != nil && !errors.Is(err, errCrash) {
+					t.Fatalf("step %v: expected crash got %v", step, err)
+				}
+				dt.pager.closeFiles()
+				f.reset()
+
+				if err := Recover(path, 64); err != nil {
+					t.Fatalf("step %v: %v", step, err)
+				}
+				dt = openTestDisk(t, path)
+				got := diskElements(t, dt)
+				switch {
+				case slices.Equal(got, after):
+					committed = true
+					checkDiskModel(t, dt, after)
+				case committed:
+					t.Fatalf("step %v: expected flushed elements to survive got %v", step, got)
+				default:
+					checkDiskModel(t, dt, before)
+				}
+				if err := dt.Close(); err != nil {
+					t.Fatal(err)
+				}
+				if err == nil {
+					if !committed {
+						t.Fatalf("step %v: expected flush without crash to persist", step)
+					}
+					return
+				}
+			}
+		})
+	}
+}
+
+// TestRecoverChecksum corrupts a committed frame in the WAL and checks that
+// recovery discards the transaction instead of writing the frame to the file.
+func TestRecoverChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openTestDisk(t, path)
+	if err := insertAll(dt, []int64{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first write to the data file is in the checkpoint, after the
+	// transaction is committed to the WAL.
+	f := &faults{}
+	f.reset()
+	injectFaults(t, f)
+	dt = openTestDisk(t, path)
+	dt.pager.data.(*faultStorage).faults = &faults{}
+	if err := insertAll(dt, []int64{4, 5, 6}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.Flush(); !errors.Is(err, errCrash) {
+		t.Fatalf("expected crash got %v", err)
+	}
+	dt.pager.closeFiles()
+
+	wal, err := os.OpenFile(walPath(path), os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := []byte{0}
+	offset := int64(walHeaderSize + frameHeaderSize)
+	if _, err := wal.ReadAt(b, offset); err != nil {
+		t.Fatal(err)
+	}
+	b[0]++
+	if _, err := wal.WriteAt(b, offset); err != nil {
+		t.Fatal(err)
+	}
+	wal.Close()
+
+	if err := Recover(path, 64); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(walPath(path)); err != nil || info.Size() != 0 {
+		t.Fatalf("expected empty WAL got %v, %v", info, err)
+	}
+	dt = openTestDisk(t, path)
+	defer dt.Close()
+	checkDiskModel(t, dt, []int64{1, 2, 3})
+}
+
+func TestRecoverErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	dt := openTestDisk(t, path)
+	if err := insertAll(dt, []int64{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// Leave a committed transaction in the WAL.
+	if err := insertAll(dt, []int64{4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.writeMeta(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.pager.commit(); err != nil {
+		t.Fatal(err)
+	}
+	dt.pager.closeFiles()
+
+	if err := Recover(path, 128); err == nil {
+		t.Fatal("expected error for wrong page size")
+	}
+	if err := Recover(path, 64); err != nil {
+		t.Fatal(err)
+	}
+	dt = openTestDisk(t, path)
+	defer dt.Close()
+	checkDiskModel(t, dt, []int64{1, 2, 3, 4})
+}
+
+func insertAll(dt *diskTree[int64], values []int64) error {
+	for _, v := range values {
+		if err := dt.Insert(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diskElements(t *testing.T, dt *diskTree[int64]) []int64 {
+	t.Helper()
+	got := []int64{}
+	err := dt.AllFunc(func(e int64) bool {
+		got = append(got, e)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
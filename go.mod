@@ -1,3 +1,3 @@
 module github.com/chirst/al-go-rithms
 
-go 1.20
+go 1.21
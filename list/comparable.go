@@ -0,0 +1,92 @@
+package list
+
+// Comparable is a List of comparable values. It has every method of List along
+// with helpers that rely on comparing values with ==.
+//
+// The zero value is an empty list ready to use.
+type Comparable[T comparable] struct {
+	List[T]
+}
+
+// NewComparable returns an instance of a comparable list with the given values.
+//
+// The complexity is O(n).
+func NewComparable[T comparable](values ...T) *Comparable[T] {
+	c := &Comparable[T]{}
+	for _, v := range values {
+		c.Append(v)
+	}
+	return c
+}
+
+// Equal reports whether the list and other have the same length and equal
+// values in the same order.
+//
+// The complexity is O(n).
+func (c *Comparable[T]) Equal(other *List[T]) bool {
+	if c.Len() != other.Len() {
+		return false
+	}
+	a, b := c.head, other.head
+	for a != nil {
+		if a.value != b.value {
+			return false
+		}
+		a, b = a.next, b.next
+	}
+	return true
+}
+
+// IndexOf returns the zero based index of the first element equal to value or
+// -1 when no element is equal to value.
+//
+// The complexity is O(n).
+func (c *Comparable[T]) IndexOf(value T) int {
+	i := 0
+	for e := c.head; e != nil; e = e.next {
+		if e.value == value {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// LastIndexOf returns the zero based index of the last element equal to value
+// or -1 when no element is equal to value. The search starts from the end of
+// the list.
+//
+// The complexity is O(n).
+func (c *Comparable[T]) LastIndexOf(value T) int {
+	i := c.len - 1
+	for e := c.tail; e != nil; e = e.prev {
+		if e.value == value {
+			return i
+		}
+		i--
+	}
+	return -1
+}
+
+// Contains reports whether an element is equal to value.
+//
+// The complexity is O(n).
+func (c *Comparable[T]) Contains(value T) bool {
+	return c.IndexOf(value) != -1
+}
+
+// RemoveValue removes the first element equal to value.
+//
+// Returns true when an element was removed.
+//
+// The complexity is O(n).
+func (c *Comparable[T]) RemoveValue(value T) bool {
+	e := c.Find(func(v T) bool {
+		return v == value
+	})
+	if e == nil {
+		return false
+	}
+	c.RemoveElement(e)
+	return true
+}
@@ -0,0 +1,88 @@
+package list
+
+import (
+	"testing"
+)
+
+func TestComparableEqual(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		c := NewComparable(1, 2, 3)
+		if !c.Equal(New(1, 2, 3)) {
+			t.Error("expected lists to be equal")
+		}
+	})
+
+	t.Run("different values", func(t *testing.T) {
+		c := NewComparable(1, 2, 3)
+		if c.Equal(New(1, 3, 2)) {
+			t.Error("did not expect lists to be equal")
+		}
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		c := NewComparable(1, 2, 3)
+		if c.Equal(New(1, 2)) {
+			t.Error("did not expect lists to be equal")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var c Comparable[int]
+		if !c.Equal(&List[int]{}) {
+			t.Error("expected empty lists to be equal")
+		}
+	})
+
+	t.Run("list methods", func(t *testing.T) {
+		c := NewComparable(1, 2)
+		c.Append(3)
+		c.Shift()
+		if !c.Equal(New(2, 3)) {
+			t.Error("expected lists to be equal")
+		}
+		checkLen(t, &c.List, 2)
+	})
+}
+
+func TestIndexOf(t *testing.T) {
+	c := NewComparable(1, 2, 3, 2, 1)
+	checkIndex(t, c.IndexOf(2), 1)
+	checkIndex(t, c.IndexOf(1), 0)
+	checkIndex(t, c.IndexOf(4), -1)
+}
+
+func TestLastIndexOf(t *testing.T) {
+	c := NewComparable(1, 2, 3, 2, 1)
+	checkIndex(t, c.LastIndexOf(2), 3)
+	checkIndex(t, c.LastIndexOf(1), 4)
+	checkIndex(t, c.LastIndexOf(4), -1)
+}
+
+func TestContains(t *testing.T) {
+	c := NewComparable("a", "b")
+	if !c.Contains("b") {
+		t.Error("expected b to be contained")
+	}
+	if c.Contains("c") {
+		t.Error("did not expect c to be contained")
+	}
+}
+
+func TestRemoveValue(t *testing.T) {
+	c := NewComparable(1, 2, 3, 2)
+	if !c.RemoveValue(2) {
+		t.Error("expected 2 to be removed")
+	}
+	checkModel(t, &c.List, []int{1, 3, 2})
+	if c.RemoveValue(4) {
+		t.Error("did not expect 4 to be removed")
+	}
+	checkModel(t, &c.List, []int{1, 3, 2})
+}
+
+func checkIndex(t *testing.T, got, want int) {
+	t.Helper()
+	if got != want {
+		t.Errorf("expected index to be %v got %v", want, got)
+	}
+}
@@ -0,0 +1,156 @@
+package list
+
+// Deque is a double ended queue. Values can be added and removed at both ends.
+type Deque[T any] interface {
+	// PushFront adds a value to the front.
+	PushFront(value T)
+	// PushBack adds a value to the back.
+	PushBack(value T)
+	// PopFront removes and returns the value at the front. Returns false when
+	// the deque is empty.
+	PopFront() (T, bool)
+	// PopBack removes and returns the value at the back. Returns false when
+	// the deque is empty.
+	PopBack() (T, bool)
+	// Front returns the value at the front. Returns false when the deque is
+	// empty.
+	Front() (T, bool)
+	// Back returns the value at the back. Returns false when the deque is
+	// empty.
+	Back() (T, bool)
+	// Len returns the count of values.
+	Len() int
+}
+
+// Stack is a last in first out collection.
+type Stack[T any] interface {
+	// Push adds a value to the top.
+	Push(value T)
+	// Pop removes and returns the value at the top. Returns false when the
+	// stack is empty.
+	Pop() (T, bool)
+	// Peek returns the value at the top. Returns false when the stack is
+	// empty.
+	Peek() (T, bool)
+	// Len returns the count of values.
+	Len() int
+}
+
+// Queue is a first in first out collection.
+type Queue[T any] interface {
+	// Enqueue adds a value to the back.
+	Enqueue(value T)
+	// Dequeue removes and returns the value at the front. Returns false when
+	// the queue is empty.
+	Dequeue() (T, bool)
+	// Peek returns the value at the front. Returns false when the queue is
+	// empty.
+	Peek() (T, bool)
+	// Len returns the count of values.
+	Len() int
+}
+
+// NewDeque returns a deque backed by a list.
+//
+// Every operation is O(1).
+func NewDeque[T any]() Deque[T] {
+	return &listDeque[T]{}
+}
+
+// NewStack returns a stack backed by a list.
+//
+// Every operation is O(1).
+func NewStack[T any]() Stack[T] {
+	return &dequeStack[T]{deque: NewDeque[T]()}
+}
+
+// NewQueue returns a queue backed by a list.
+//
+// Every operation is O(1).
+func NewQueue[T any]() Queue[T] {
+	return &dequeQueue[T]{deque: NewDeque[T]()}
+}
+
+// listDeque is a deque backed by a list.
+type listDeque[T any] struct {
+	list List[T]
+}
+
+func (d *listDeque[T]) PushFront(value T) {
+	d.list.Prepend(value)
+}
+
+func (d *listDeque[T]) PushBack(value T) {
+	d.list.Append(value)
+}
+
+func (d *listDeque[T]) PopFront() (T, bool) {
+	return d.list.Shift()
+}
+
+func (d *listDeque[T]) PopBack() (T, bool) {
+	return d.list.Pop()
+}
+
+func (d *listDeque[T]) Front() (T, bool) {
+	if d.list.head == nil {
+		var zero T
+		return zero, false
+	}
+	return d.list.head.value, true
+}
+
+func (d *listDeque[T]) Back() (T, bool) {
+	if d.list.tail == nil {
+		var zero T
+		return zero, false
+	}
+	return d.list.tail.value, true
+}
+
+func (d *listDeque[T]) Len() int {
+	return d.list.Len()
+}
+
+// dequeStack is a stack using the back of a deque as its top.
+type dequeStack[T any] struct {
+	deque Deque[T]
+}
+
+func (s *dequeStack[T]) Push(value T) {
+	s.deque.PushBack(value)
+}
+
+func (s *dequeStack[T]) Pop() (T, bool) {
+	return s.deque.PopBack()
+}
+
+func (s *dequeStack[T]) Peek() (T, bool) {
+	return s.deque.Back()
+}
+
+func (s *dequeStack[T]) Len() int {
+	return s.deque.Len()
+}
+
+// dequeQueue is a queue adding to the back of a deque and removing from the
+// front.
+type dequeQueue[T any] struct {
+	deque Deque[T]
+}
+
+func (q *dequeQueue[T]) Enqueue(value T) {
+	q.deque.PushBack(value)
+}
+
+func (q *dequeQueue[T]) Dequeue() (T, bool) {
+	return q.deque.PopFront()
+}
+
+func (q *dequeQueue[T]) Peek() (T, bool) {
+	return q.deque.Front()
+}
+
+func (q *dequeQueue[T]) Len() int {
+	return q.deque.Len()
+}
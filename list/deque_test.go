@@ -0,0 +1,154 @@
+package list
+
+import (
+	"fmt"
+	"testing"
+)
+
+// deques are the deque implementations every deque test runs against.
+var deques = []struct {
+	name string
+	new  func() Deque[int]
+}{
+	{"list", NewDeque[int]},
+	{"ring", func() Deque[int] { return NewRingDeque[int](2) }},
+}
+
+func TestDeque(t *testing.T) {
+	for _, impl := range deques {
+		t.Run(impl.name, func(t *testing.T) {
+			d := impl.new()
+			checkMissing(t, "pop front", d.PopFront)
+			checkMissing(t, "pop back", d.PopBack)
+			checkMissing(t, "front", d.Front)
+			checkMissing(t, "back", d.Back)
+
+			// Pushing to both ends wraps the ring buffer around and grows
+			// it.
+			for i := 1; i <= 5; i++ {
+				d.PushBack(i)
+				d.PushFront(-i)
+			}
+			if d.Len() != 10 {
+				t.Errorf("expected len to be 10 got %v", d.Len())
+			}
+			checkPresent(t, "front", d.Front, -5)
+			checkPresent(t, "back", d.Back, 5)
+			for i := 5; 1 <= i; i-- {
+				checkPresent(t, "pop front", d.PopFront, -i)
+			}
+			for i := 5; 1 <= i; i-- {
+				checkPresent(t, "pop back", d.PopBack, i)
+			}
+			if d.Len() != 0 {
+				t.Errorf("expected len to be 0 got %v", d.Len())
+			}
+			checkMissing(t, "pop back", d.PopBack)
+		})
+	}
+}
+
+func TestStack(t *testing.T) {
+	stacks := map[string]Stack[int]{
+		"list": NewStack[int](),
+		"ring": NewRingStack[int](0),
+	}
+	for name, s := range stacks {
+		t.Run(name, func(t *testing.T) {
+			checkMissing(t, "peek", s.Peek)
+			for i := 1; i <= 3; i++ {
+				s.Push(i)
+			}
+			checkPresent(t, "peek", s.Peek, 3)
+			checkPresent(t, "pop", s.Pop, 3)
+			checkPresent(t, "pop", s.Pop, 2)
+			s.Push(4)
+			checkPresent(t, "pop", s.Pop, 4)
+			checkPresent(t, "pop", s.Pop, 1)
+			checkMissing(t, "pop", s.Pop)
+			if s.Len() != 0 {
+				t.Errorf("expected len to be 0 got %v", s.Len())
+			}
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	queues := map[string]Queue[int]{
+		"list": NewQueue[int](),
+		"ring": NewRingQueue[int](2),
+	}
+	for name, q := range queues {
+		t.Run(name, func(t *testing.T) {
+			checkMissing(t, "peek", q.Peek)
+			for i := 1; i <= 3; i++ {
+				q.Enqueue(i)
+			}
+			checkPresent(t, "peek", q.Peek, 1)
+			checkPresent(t, "dequeue", q.Dequeue, 1)
+			q.Enqueue(4)
+			for i := 2; i <= 4; i++ {
+				checkPresent(t, "dequeue", q.Dequeue, i)
+			}
+			checkMissing(t, "dequeue", q.Dequeue)
+			if q.Len() != 0 {
+				t.Errorf("expected len to be 0 got %v", q.Len())
+			}
+		})
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	queues := map[string]func() Queue[int]{
+		"list": NewQueue[int],
+		"ring": func() Queue[int] { return NewRingQueue[int](16) },
+	}
+	for _, name := range []string{"list", "ring"} {
+		for _, size := range []int{16, 1024} {
+			b.Run(fmt.Sprintf("%v size %v", name, size), func(b *testing.B) {
+				q := queues[name]()
+				for i := 0; i < size; i++ {
+					q.Enqueue(i)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					q.Enqueue(i)
+					q.Dequeue()
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkStack(b *testing.B) {
+	stacks := map[string]func() Stack[int]{
+		"list": NewStack[int],
+		"ring": func() Stack[int] { return NewRingStack[int](16) },
+	}
+	for _, name := range []string{"list", "ring"} {
+		b.Run(name, func(b *testing.B) {
+			s := stacks[name]()
+			for i := 0; i < b.N; i++ {
+				s.Push(i)
+			}
+			for i := 0; i < b.N; i++ {
+				s.Pop()
+			}
+		})
+	}
+}
+
+func checkPresent(t *testing.T, op string, f func() (int, bool), want int) {
+	t.Helper()
+	got, ok := f()
+	if !ok || got != want {
+		t.Errorf("expected %v to return %v got %v, %v", op, want, got, ok)
+	}
+}
+
+func checkMissing(t *testing.T, op string, f func() (int, bool)) {
+	t.Helper()
+	if got, ok := f(); ok {
+		t.Errorf("expected %v to return nothing got %v", op, got)
+	}
+}
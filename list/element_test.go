@@ -0,0 +1,141 @@
+package list
+
+import (
+	"testing"
+)
+
+func TestElementNavigation(t *testing.T) {
+	l := New[int]()
+	e1 := l.Append(1)
+	e2 := l.Append(2)
+	e0 := l.Prepend(0)
+
+	if l.Front() != e0 || l.Back() != e2 {
+		t.Error("expected front and back to be the first and last elements")
+	}
+	if e0.Next() != e1 || e1.Next() != e2 || e2.Next() != nil {
+		t.Error("expected next to walk forward")
+	}
+	if e2.Prev() != e1 || e1.Prev() != e0 || e0.Prev() != nil {
+		t.Error("expected prev to walk backward")
+	}
+	if e1.Value() != 1 {
+		t.Errorf("expected value to be 1 got %v", e1.Value())
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		var l List[int]
+		if l.Front() != nil || l.Back() != nil {
+			t.Error("expected front and back of an empty list to be nil")
+		}
+	})
+}
+
+func TestInsertBefore(t *testing.T) {
+	l := New[int]()
+	e3 := l.Append(3)
+	l.InsertBefore(1, e3)
+	l.InsertBefore(2, e3)
+	checkModel(t, l, []int{1, 2, 3})
+}
+
+func TestInsertAfter(t *testing.T) {
+	l := New[int]()
+	e1 := l.Append(1)
+	l.InsertAfter(3, e1)
+	e2 := l.InsertAfter(2, e1)
+	checkModel(t, l, []int{1, 2, 3})
+	if e2.Value() != 2 {
+		t.Errorf("expected inserted element value to be 2 got %v", e2.Value())
+	}
+}
+
+func TestRemoveElement(t *testing.T) {
+	l := New[int]()
+	e1 := l.Append(1)
+	e2 := l.Append(2)
+	e3 := l.Append(3)
+
+	if v := l.RemoveElement(e2); v != 2 {
+		t.Errorf("expected removed value to be 2 got %v", v)
+	}
+	checkModel(t, l, []int{1, 3})
+	l.RemoveElement(e1)
+	checkModel(t, l, []int{3})
+	l.RemoveElement(e3)
+	checkModel(t, l, []int{})
+
+	t.Run("twice", func(t *testing.T) {
+		l := New(1, 2)
+		e := l.Front()
+		l.RemoveElement(e)
+		l.RemoveElement(e)
+		checkModel(t, l, []int{2})
+		if e.Next() != nil {
+			t.Error("expected next of a removed element to be nil")
+		}
+	})
+}
+
+func TestMove(t *testing.T) {
+	t.Run("to front", func(t *testing.T) {
+		l := New(1, 2, 3)
+		l.MoveToFront(l.Back())
+		checkModel(t, l, []int{3, 1, 2})
+		l.MoveToFront(l.Front())
+		checkModel(t, l, []int{3, 1, 2})
+	})
+
+	t.Run("to back", func(t *testing.T) {
+		l := New(1, 2, 3)
+		l.MoveToBack(l.Front())
+		checkModel(t, l, []int{2, 3, 1})
+		l.MoveToBack(l.Back())
+		checkModel(t, l, []int{2, 3, 1})
+	})
+
+	t.Run("after", func(t *testing.T) {
+		l := New(1, 2, 3, 4)
+		first, last := l.Front(), l.Back()
+		l.MoveAfter(first, last)
+		checkModel(t, l, []int{2, 3, 4, 1})
+		l.MoveAfter(last, first)
+		checkModel(t, l, []int{2, 3, 1, 4})
+		l.MoveAfter(first, first)
+		checkModel(t, l, []int{2, 3, 1, 4})
+		l.MoveAfter(l.Front().Next(), l.Front())
+		checkModel(t, l, []int{2, 3, 1, 4})
+	})
+}
+
+func TestForeignElement(t *testing.T) {
+	l := New(1, 2, 3)
+	other := New(4)
+	foreign := other.Front()
+
+	if l.InsertBefore(5, foreign) != nil {
+		t.Error("expected insert before a foreign element to be rejected")
+	}
+	if l.InsertAfter(5, foreign) != nil {
+		t.Error("expected insert after a foreign element to be rejected")
+	}
+	l.RemoveElement(foreign)
+	l.MoveToFront(foreign)
+	l.MoveToBack(foreign)
+	l.MoveAfter(foreign, l.Front())
+	l.MoveAfter(l.Front(), foreign)
+
+	checkModel(t, l, []int{1, 2, 3})
+	checkModel(t, other, []int{4})
+
+	t.Run("removed", func(t *testing.T) {
+		l := New(1, 2, 3)
+		removed := l.Front()
+		l.Shift()
+		if l.InsertAfter(4, removed) != nil {
+			t.Error("expected insert after a removed element to be rejected")
+		}
+		l.MoveToBack(removed)
+		checkModel(t, l, []int{2, 3})
+	})
+}
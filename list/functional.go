@@ -0,0 +1,82 @@
+package list
+
+// Find returns the first element where pred returns true or nil when there is
+// no such element.
+//
+// The complexity is O(n).
+func (ll *List[T]) Find(pred func(T) bool) *Element[T] {
+	for e := ll.head; e != nil; e = e.next {
+		if pred(e.value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// RemoveIf removes every element where pred returns true.
+//
+// Returns the count of removed elements.
+//
+// The complexity is O(n).
+func (ll *List[T]) RemoveIf(pred func(T) bool) int {
+	removed := 0
+	for e := ll.head; e != nil; {
+		next := e.next
+		if pred(e.value) {
+			ll.RemoveElement(e)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// Map returns a new list with the result of calling f on every value of l.
+//
+// The complexity is O(n).
+func Map[T, U any](l *List[T], f func(T) U) *List[U] {
+	mapped := New[U]()
+	for e := l.head; e != nil; e = e.next {
+		mapped.Append(f(e.value))
+	}
+	return mapped
+}
+
+// Filter returns a new list with the values of l where pred returns true.
+//
+// The complexity is O(n).
+func Filter[T any](l *List[T], pred func(T) bool) *List[T] {
+	filtered := New[T]()
+	for e := l.head; e != nil; e = e.next {
+		if pred(e.value) {
+			filtered.Append(e.value)
+		}
+	}
+	return filtered
+}
+
+// Reduce combines the values of l from first to last into a single value by
+// calling f with the result so far and the next value, starting with initial.
+//
+// The complexity is O(n).
+func Reduce[T, A any](l *List[T], initial A, f func(A, T) A) A {
+	result := initial
+	for e := l.head; e != nil; e = e.next {
+		result = f(result, e.value)
+	}
+	return result
+}
+
+// FlatMap returns a new list made of the values of every list returned by
+// calling f on every value of l.
+//
+// The complexity is O(n + m) where m is the count of mapped values.
+func FlatMap[T, U any](l *List[T], f func(T) *List[U]) *List[U] {
+	flat := New[U]()
+	for e := l.head; e != nil; e = e.next {
+		for m := f(e.value).head; m != nil; m = m.next {
+			flat.Append(m.value)
+		}
+	}
+	return flat
+}
@@ -0,0 +1,74 @@
+package list
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	e := l.Find(func(v int) bool { return 2 < v })
+	if e == nil || e.Value() != 3 {
+		t.Errorf("expected to find 3 got %v", e)
+	}
+	if e := l.Find(func(v int) bool { return 4 < v }); e != nil {
+		t.Errorf("did not expect to find %v", e.Value())
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	t.Run("some", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5, 6)
+		removed := l.RemoveIf(func(v int) bool { return v%2 == 0 })
+		if removed != 3 {
+			t.Errorf("expected 3 removed got %v", removed)
+		}
+		checkModel(t, l, []int{1, 3, 5})
+	})
+
+	t.Run("all", func(t *testing.T) {
+		l := New(1, 2, 3)
+		l.RemoveIf(func(int) bool { return true })
+		checkModel(t, l, []int{})
+	})
+}
+
+func TestMap(t *testing.T) {
+	l := New(1, 2, 3)
+	m := Map(l, strconv.Itoa)
+	if got := m.Slice(); len(got) != 3 || got[0] != "1" || got[1] != "2" || got[2] != "3" {
+		t.Errorf("expected [1 2 3] got %v", got)
+	}
+	checkModel(t, l, []int{1, 2, 3})
+}
+
+func TestFilter(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	f := Filter(l, func(v int) bool { return v%2 == 1 })
+	checkModel(t, f, []int{1, 3})
+	checkModel(t, l, []int{1, 2, 3, 4})
+}
+
+func TestReduce(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	sum := Reduce(l, 0, func(sum, v int) int { return sum + v })
+	if sum != 10 {
+		t.Errorf("expected sum to be 10 got %v", sum)
+	}
+	joined := Reduce(l, "", func(s string, v int) string { return s + strconv.Itoa(v) })
+	if joined != "1234" {
+		t.Errorf("expected joined to be 1234 got %v", joined)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	l := New(1, 2, 3)
+	f := FlatMap(l, func(v int) *List[int] {
+		repeated := New[int]()
+		for i := 0; i < v; i++ {
+			repeated.Append(v)
+		}
+		return repeated
+	})
+	checkModel(t, f, []int{1, 2, 2, 3, 3, 3})
+}
@@ -0,0 +1,114 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+// FuzzListOps decodes data into a sequence of operations on a list and checks
+// the list against a slice after every operation.
+//
+// Every three bytes are an operation followed by two arguments. Indexes are
+// decoded so they are sometimes negative or out of range.
+func FuzzListOps(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		l := New[int]()
+		model := []int{}
+		for 3 <= len(data) {
+			op, a, b := data[0]%7, int(data[1]), int(data[2])
+			data = data[3:]
+			// index is between -len - 1 and len + 1 so it can be out of range at
+			// either end.
+			index := func(v int) int {
+				return v%(2*len(model)+3) - len(model) - 1
+			}
+			// resolve turns a negative index into an index from the start.
+			resolve := func(i int) int {
+				if i < 0 {
+					return i + len(model)
+				}
+				return i
+			}
+			inRange := func(i int) bool {
+				return 0 <= resolve(i) && resolve(i) < len(model)
+			}
+			switch op {
+			case 0:
+				l.Append(a)
+				model = append(model, a)
+			case 1:
+				l.Prepend(a)
+				model = slices.Insert(model, 0, a)
+			case 2:
+				i := index(a)
+				l.Insert(i, b)
+				if 0 <= resolve(i) && resolve(i) <= len(model) {
+					model = slices.Insert(model, resolve(i), b)
+				}
+			case 3:
+				i := index(a)
+				got, ok := l.Remove(i)
+				if inRange(i) {
+					i = resolve(i)
+					checkModelValue(t, "remove", got, ok, model[i])
+					model = slices.Delete(model, i, i+1)
+				} else {
+					checkModelNil(t, "remove", got, ok)
+				}
+			case 4:
+				i, j := index(a), index(b)
+				l.Swap(i, j)
+				if inRange(i) && inRange(j) {
+					i, j = resolve(i), resolve(j)
+					model[i], model[j] = model[j], model[i]
+				}
+			case 5:
+				got, ok := l.Pop()
+				if len(model) != 0 {
+					checkModelValue(t, "pop", got, ok, model[len(model)-1])
+					model = model[:len(model)-1]
+				} else {
+					checkModelNil(t, "pop", got, ok)
+				}
+			case 6:
+				got, ok := l.Shift()
+				if len(model) != 0 {
+					checkModelValue(t, "shift", got, ok, model[0])
+					model = model[1:]
+				} else {
+					checkModelNil(t, "shift", got, ok)
+				}
+			}
+			checkModel(t, l, model)
+		}
+	})
+}
+
+// checkModel asserts the list is valid and holds exactly the values of the
+// model.
+func checkModel(t *testing.T, l *List[int], model []int) {
+	t.Helper()
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != len(model) {
+		t.Fatalf("expected len to be %v got %v", len(model), l.Len())
+	}
+	if got := l.Slice(); !slices.Equal(got, model) {
+		t.Fatalf("expected values to be %v got %v", model, got)
+	}
+}
+
+func checkModelValue(t *testing.T, op string, got int, ok bool, want int) {
+	t.Helper()
+	if !ok || got != want {
+		t.Fatalf("expected %v to return %v got %v", op, want, got)
+	}
+}
+
+func checkModelNil(t *testing.T, op string, got int, ok bool) {
+	t.Helper()
+	if ok {
+		t.Fatalf("expected %v to return nothing got %v", op, got)
+	}
+}
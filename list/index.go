@@ -0,0 +1,119 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIndexOutOfRange is returned when an index is not in the set of indexes of a
+// list. The returned error wraps ErrIndexOutOfRange with the offending index
+// and the length of the list.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// TryInsert inserts an element for a zero based index the same as Insert.
+//
+// Returns an error wrapping ErrIndexOutOfRange when the index is not between
+// -Len and Len inclusive.
+//
+// The complexity is O(n).
+func (ll *List[T]) TryInsert(index int, value T) error {
+	// Inserting at Len is allowed, it appends.
+	i, err := ll.resolveIndex(index, ll.len+1)
+	if err != nil {
+		return err
+	}
+	if i == ll.len {
+		ll.Append(value)
+		return nil
+	}
+	ll.InsertBefore(value, ll.elementAt(i))
+	return nil
+}
+
+// TryRemove removes an element for a zero based index the same as Remove.
+//
+// Returns the value of the removed element or an error wrapping
+// ErrIndexOutOfRange when the index is not in the set of indexes.
+//
+// The complexity is O(n).
+func (ll *List[T]) TryRemove(index int) (T, error) {
+	i, err := ll.resolveIndex(index, ll.len)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return ll.RemoveElement(ll.elementAt(i)), nil
+}
+
+// TrySwap swaps two elements for two zero based indexes the same as Swap.
+//
+// Returns an error wrapping ErrIndexOutOfRange when either index is not in the
+// set of indexes.
+//
+// The complexity is O(n).
+func (ll *List[T]) TrySwap(indexA, indexB int) error {
+	a, err := ll.resolveIndex(indexA, ll.len)
+	if err != nil {
+		return err
+	}
+	b, err := ll.resolveIndex(indexB, ll.len)
+	if err != nil {
+		return err
+	}
+	elementA, elementB := ll.elementAt(a), ll.elementAt(b)
+	elementA.value, elementB.value = elementB.value, elementA.value
+	return nil
+}
+
+// At returns the value of an element for a zero based index.
+//
+// Negative indexes count back from the end of the list, -1 is the last
+// element.
+//
+// Returns an error wrapping ErrIndexOutOfRange when the index is not in the set
+// of indexes.
+//
+// The complexity is O(n).
+func (ll *List[T]) At(index int) (T, error) {
+	i, err := ll.resolveIndex(index, ll.len)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return ll.elementAt(i).value, nil
+}
+
+// resolveIndex turns a negative index into an index counted from the start of
+// the list and checks that it is less than limit.
+//
+// The error reports the index as given by the caller and the length of the
+// list.
+func (ll *List[T]) resolveIndex(index, limit int) (int, error) {
+	i := index
+	if i < 0 {
+		i += ll.len
+	}
+	if i < 0 || limit <= i {
+		return 0, fmt.Errorf("%w: index %v with length %v", ErrIndexOutOfRange, index, ll.len)
+	}
+	return i, nil
+}
+
+// elementAt returns the element for a zero based index that must be in the set
+// of indexes. The list is walked from whichever end is closer to the index.
+//
+// The complexity is O(n).
+func (ll *List[T]) elementAt(index int) *Element[T] {
+	if index < ll.len/2 {
+		e := ll.head
+		for i := 0; i < index; i++ {
+			e = e.next
+		}
+		return e
+	}
+	e := ll.tail
+	for i := ll.len - 1; index < i; i-- {
+		e = e.prev
+	}
+	return e
+}
@@ -0,0 +1,106 @@
+package list
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNegativeIndex(t *testing.T) {
+	t.Run("insert", func(t *testing.T) {
+		l := New(1, 2, 3)
+		l.Insert(-1, 4)
+		checkModel(t, l, []int{1, 2, 4, 3})
+		l.Insert(-4, 5)
+		checkModel(t, l, []int{5, 1, 2, 4, 3})
+		l.Insert(-6, 6)
+		checkModel(t, l, []int{5, 1, 2, 4, 3})
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		l := New(1, 2, 3)
+		r, ok := l.Remove(-2)
+		checkEqual(t, r, ok, 2)
+		checkModel(t, l, []int{1, 3})
+		r, ok = l.Remove(-3)
+		checkAbsent(t, r, ok)
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		l := New(1, 2, 3)
+		l.Swap(0, -1)
+		checkModel(t, l, []int{3, 2, 1})
+	})
+
+	t.Run("get", func(t *testing.T) {
+		l := New(1, 2, 3)
+		r, ok := l.Get(-1)
+		checkEqual(t, r, ok, 3)
+		r, ok = l.Get(-3)
+		checkEqual(t, r, ok, 1)
+		r, ok = l.Get(-4)
+		checkAbsent(t, r, ok)
+	})
+}
+
+func TestTryInsert(t *testing.T) {
+	l := New(1, 2, 3)
+	if err := l.TryInsert(3, 4); err != nil {
+		t.Errorf("expected no error got %v", err)
+	}
+	checkModel(t, l, []int{1, 2, 3, 4})
+	checkIndexError(t, l.TryInsert(5, 5), "index out of range: index 5 with length 4")
+	checkIndexError(t, l.TryInsert(-5, 5), "index out of range: index -5 with length 4")
+	checkModel(t, l, []int{1, 2, 3, 4})
+}
+
+func TestTryRemove(t *testing.T) {
+	l := New(1, 2, 3)
+	v, err := l.TryRemove(1)
+	if err != nil || v != 2 {
+		t.Errorf("expected to remove 2 got %v, %v", v, err)
+	}
+	_, err = l.TryRemove(2)
+	checkIndexError(t, err, "index out of range: index 2 with length 2")
+	checkModel(t, l, []int{1, 3})
+
+	empty := New[int]()
+	_, err = empty.TryRemove(0)
+	checkIndexError(t, err, "index out of range: index 0 with length 0")
+}
+
+func TestTrySwap(t *testing.T) {
+	l := New(1, 2, 3)
+	if err := l.TrySwap(0, 2); err != nil {
+		t.Errorf("expected no error got %v", err)
+	}
+	checkModel(t, l, []int{3, 2, 1})
+	checkIndexError(t, l.TrySwap(0, 3), "index out of range: index 3 with length 3")
+	checkIndexError(t, l.TrySwap(-4, 0), "index out of range: index -4 with length 3")
+	checkModel(t, l, []int{3, 2, 1})
+}
+
+func TestAt(t *testing.T) {
+	l := New(1, 2, 3, 4, 5)
+	for i := -5; i < 5; i++ {
+		want := i + 1
+		if i < 0 {
+			want = i + 6
+		}
+		v, err := l.At(i)
+		if err != nil || v != want {
+			t.Errorf("expected value at %v to be %v got %v, %v", i, want, v, err)
+		}
+	}
+	_, err := l.At(5)
+	checkIndexError(t, err, "index out of range: index 5 with length 5")
+}
+
+func checkIndexError(t *testing.T, err error, want string) {
+	t.Helper()
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Fatalf("expected error to wrap ErrIndexOutOfRange got %v", err)
+	}
+	if err.Error() != want {
+		t.Errorf("expected error %q got %q", want, err)
+	}
+}
@@ -0,0 +1,73 @@
+//go:build go1.23
+
+package list
+
+import "iter"
+
+// All returns an iterator over the indexes and values of the list from the
+// first element to the last.
+//
+// The element being visited may be removed during iteration, in which case
+// iteration continues with the element that followed it. Any other
+// modification of the list during iteration panics.
+func (ll *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for e := ll.head; e != nil; {
+			next, mods := e.next, ll.mods
+			if !yield(i, e.value) {
+				return
+			}
+			if !ll.removedDuringYield(e, mods) {
+				i++
+			}
+			e = next
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list from the first
+// element to the last.
+//
+// Modifying the list during iteration follows the same rules as All.
+func (ll *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range ll.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the indexes and values of the list from
+// the last element to the first.
+//
+// Modifying the list during iteration follows the same rules as All.
+func (ll *List[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := ll.len - 1
+		for e := ll.tail; e != nil; {
+			prev, mods := e.prev, ll.mods
+			if !yield(i, e.value) {
+				return
+			}
+			ll.removedDuringYield(e, mods)
+			i--
+			e = prev
+		}
+	}
+}
+
+// removedDuringYield reports whether e was removed while it was yielded. mods
+// is the modification count from before e was yielded. Panics when the list
+// was modified in any other way.
+func (ll *List[T]) removedDuringYield(e *Element[T], mods int) bool {
+	if ll.mods == mods {
+		return false
+	}
+	if ll.mods == mods+1 && e.owner == nil {
+		return true
+	}
+	panic("list: list modified during iteration")
+}
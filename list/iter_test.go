@@ -0,0 +1,112 @@
+//go:build go1.23
+
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	l := New(1, 2, 3)
+	indexes, values := []int{}, []int{}
+	for i, v := range l.All() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	checkSlice(t, indexes, 0, 1, 2)
+	checkSlice(t, values, 1, 2, 3)
+
+	t.Run("break", func(t *testing.T) {
+		values := []int{}
+		for _, v := range l.All() {
+			if v == 3 {
+				break
+			}
+			values = append(values, v)
+		}
+		checkSlice(t, values, 1, 2)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var l List[int]
+		for i, v := range l.All() {
+			t.Errorf("did not expect %v at %v", v, i)
+		}
+	})
+}
+
+func TestValues(t *testing.T) {
+	l := New(1, 2, 3)
+	checkSlice(t, slices.Collect(l.Values()), 1, 2, 3)
+}
+
+func TestBackward(t *testing.T) {
+	l := New(1, 2, 3)
+	indexes, values := []int{}, []int{}
+	for i, v := range l.Backward() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	checkSlice(t, indexes, 2, 1, 0)
+	checkSlice(t, values, 3, 2, 1)
+}
+
+func TestIterationModification(t *testing.T) {
+	t.Run("remove current", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5)
+		indexes := []int{}
+		for i, v := range l.All() {
+			indexes = append(indexes, i)
+			if v%2 == 0 {
+				l.Remove(i)
+			}
+		}
+		checkSlice(t, indexes, 0, 1, 1, 2, 2)
+		checkModel(t, l, []int{1, 3, 5})
+	})
+
+	t.Run("remove current backward", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5)
+		for i, v := range l.Backward() {
+			if v%2 == 1 {
+				l.Remove(i)
+			}
+		}
+		checkModel(t, l, []int{2, 4})
+	})
+
+	t.Run("shift current", func(t *testing.T) {
+		l := New(1, 2, 3)
+		values := []int{}
+		for v := range l.Values() {
+			values = append(values, v)
+			l.Shift()
+		}
+		checkSlice(t, values, 1, 2, 3)
+		checkModel(t, l, []int{})
+	})
+
+	t.Run("append", func(t *testing.T) {
+		defer checkPanic(t)
+		l := New(1, 2, 3)
+		for range l.All() {
+			l.Append(4)
+		}
+	})
+
+	t.Run("remove other", func(t *testing.T) {
+		defer checkPanic(t)
+		l := New(1, 2, 3)
+		for range l.All() {
+			l.Pop()
+		}
+	})
+}
+
+func checkPanic(t *testing.T) {
+	t.Helper()
+	if recover() == nil {
+		t.Error("expected a panic")
+	}
+}
@@ -1,26 +1,87 @@
 // Package list is a doubly linked list that shouldn't be taken too seriously.
 package list
 
-// TODO:
-// - Implement Sort
-
-type linkList[T comparable] struct {
-	head *node[T]
-	tail *node[T]
+// List is a doubly linked list of values.
+//
+// The zero value is an empty list ready to use.
+type List[T any] struct {
+	head *Element[T]
+	tail *Element[T]
 	len  int
+	// mods counts changes to the links of the list. Iterators use it to detect
+	// the list being modified while iterating.
+	mods int
+	// owner identifies the elements of the list. It is created on first use
+	// so the zero value of a list is ready to use.
+	owner *owner
 }
 
-type node[T comparable] struct {
-	prev  *node[T]
-	next  *node[T]
+// Element is a handle to a value in a list. Handles allow inserting, removing
+// and moving values around a known position in O(1) instead of walking the
+// list to an index.
+//
+// A handle belongs to the list that created it. Methods given a handle from a
+// different list, or a handle that has been removed, do nothing.
+type Element[T any] struct {
+	prev  *Element[T]
+	next  *Element[T]
 	value T
+	// owner identifies the list the element belongs to or is nil once the
+	// element has been removed.
+	owner *owner
+}
+
+// owner identifies the list an element belongs to. Concat moves every element
+// of a list in O(1) by pointing the owner of the emptied list at the owner of
+// the list the elements moved to, instead of updating every element.
+type owner struct {
+	// next is the owner this owner was merged into or nil when this owner
+	// still identifies a list.
+	next *owner
+}
+
+// resolve returns the owner that currently identifies the list. Chains left
+// by Concat are shortened along the way so later lookups are fast.
+func (o *owner) resolve() *owner {
+	root := o
+	for root.next != nil {
+		root = root.next
+	}
+	for o != root {
+		next := o.next
+		o.next = root
+		o = next
+	}
+	return root
+}
+
+// Value returns the value of the element.
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// Next returns the next element of the list or nil when e is the last element.
+func (e *Element[T]) Next() *Element[T] {
+	if e.owner == nil {
+		return nil
+	}
+	return e.next
+}
+
+// Prev returns the previous element of the list or nil when e is the first
+// element.
+func (e *Element[T]) Prev() *Element[T] {
+	if e.owner == nil {
+		return nil
+	}
+	return e.prev
 }
 
 // New returns an instance of a list with the given values.
 //
 // The complexity is O(n).
-func New[T comparable](values ...T) *linkList[T] {
-	l := &linkList[T]{}
+func New[T any](values ...T) *List[T] {
+	l := &List[T]{}
 	for _, v := range values {
 		l.Append(v)
 	}
@@ -30,33 +91,40 @@ func New[T comparable](values ...T) *linkList[T] {
 // Len returns the count of elements in the list.
 //
 // The complexity is O(1).
-func (ll *linkList[T]) Len() int {
+func (ll *List[T]) Len() int {
 	return ll.len
 }
 
+// Front returns the first element of the list or nil when the list is empty.
+//
+// The complexity is O(1).
+func (ll *List[T]) Front() *Element[T] {
+	return ll.head
+}
+
+// Back returns the last element of the list or nil when the list is empty.
+//
+// The complexity is O(1).
+func (ll *List[T]) Back() *Element[T] {
+	return ll.tail
+}
+
 // Prepend creates a new element at the beginning of the list.
 //
+// Returns the new element.
+//
 // The complexity is O(1).
-func (ll *linkList[T]) Prepend(value T) {
-	ll.len++
-	if ll.head != nil {
-		oldHead := ll.head
-		ll.head = &node[T]{
-			next:  oldHead,
-			value: value,
-		}
-		ll.head.next.prev = ll.head
-		return
-	}
-	ll.head = &node[T]{
-		value: value,
-	}
-	ll.tail = ll.head
+func (ll *List[T]) Prepend(value T) *Element[T] {
+	return ll.insertBetween(value, nil, ll.head)
 }
 
 // Insert inserts an element for a zero based index.
 //
-// Given the index is not in the set of indexes no item will be inserted.
+// Negative indexes count back from the end of the list, inserting before the
+// element at that position.
+//
+// Given the index is not in the set of indexes no item will be inserted. Use
+// TryInsert to find out whether an item was inserted.
 //
 // The complexity is O(n).
 //
@@ -65,170 +133,259 @@ func (ll *linkList[T]) Prepend(value T) {
 //	- given [1, 2, 3] Insert(1, 4) = [1, 4, 2, 3].
 //	- given [1, 2, 3] Insert(2, 4) = [1, 2, 4, 3].
 //	- given [1, 2, 3] Insert(3, 4) = [1, 2, 3, 4].
-func (ll *linkList[T]) Insert(index int, value T) {
-	if index == 0 {
-		ll.Prepend(value)
-		return
+//	- given [1, 2, 3] Insert(-1, 4) = [1, 2, 4, 3].
+func (ll *List[T]) Insert(index int, value T) {
+	ll.TryInsert(index, value)
+}
+
+// Append adds a new element to the end of the list.
+//
+// Returns the new element.
+//
+// The complexity is O(1).
+func (ll *List[T]) Append(value T) *Element[T] {
+	return ll.insertBetween(value, ll.tail, nil)
+}
+
+// InsertBefore inserts a new element with the given value immediately before
+// mark.
+//
+// Returns the new element or nil when mark is not an element of the list.
+//
+// The complexity is O(1).
+func (ll *List[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if !ll.owns(mark) {
+		return nil
 	}
-	if index == ll.Len() {
-		ll.Append(value)
-		return
+	return ll.insertBetween(value, mark.prev, mark)
+}
+
+// InsertAfter inserts a new element with the given value immediately after
+// mark.
+//
+// Returns the new element or nil when mark is not an element of the list.
+//
+// The complexity is O(1).
+func (ll *List[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if !ll.owns(mark) {
+		return nil
 	}
+	return ll.insertBetween(value, mark, mark.next)
+}
 
-	currentNode := ll.head
-	currentIndex := 1
-	for currentNode != nil {
-		if currentIndex == index {
-			next := currentNode.next
-			nn := &node[T]{
-				prev:  currentNode,
-				next:  next,
-				value: value,
-			}
-			currentNode.next = nn
-			next.prev = nn
-			return
-		}
-		currentNode = currentNode.next
-		currentIndex++
+// RemoveElement removes e from the list.
+//
+// Returns the value of e. Given e is not an element of the list nothing is
+// removed.
+//
+// The complexity is O(1).
+func (ll *List[T]) RemoveElement(e *Element[T]) T {
+	if ll.owns(e) {
+		ll.unlink(e)
+		e.owner = nil
 	}
+	return e.value
 }
 
-// Append adds a new element to the end of the list.
+// MoveToFront moves e to the beginning of the list.
+//
+// Given e is not an element of the list the list is not modified.
 //
 // The complexity is O(1).
-func (ll *linkList[T]) Append(value T) {
-	ll.len++
-	if ll.head == nil {
-		ll.head = &node[T]{
-			value: value,
-		}
-		ll.tail = ll.head
+func (ll *List[T]) MoveToFront(e *Element[T]) {
+	if !ll.owns(e) || ll.head == e {
 		return
 	}
-	ll.tail.next = &node[T]{
-		prev:  ll.tail,
-		value: value,
+	ll.unlink(e)
+	ll.link(e, nil, ll.head)
+}
+
+// MoveToBack moves e to the end of the list.
+//
+// Given e is not an element of the list the list is not modified.
+//
+// The complexity is O(1).
+func (ll *List[T]) MoveToBack(e *Element[T]) {
+	if !ll.owns(e) || ll.tail == e {
+		return
+	}
+	ll.unlink(e)
+	ll.link(e, ll.tail, nil)
+}
+
+// MoveAfter moves e to the position immediately after mark.
+//
+// Given e or mark is not an element of the list, or e is mark, the list is not
+// modified.
+//
+// The complexity is O(1).
+func (ll *List[T]) MoveAfter(e, mark *Element[T]) {
+	if !ll.owns(e) || !ll.owns(mark) || e == mark {
+		return
 	}
-	ll.tail = ll.tail.next
+	ll.unlink(e)
+	ll.link(e, mark, mark.next)
 }
 
 // Shift removes the first element in the list.
 //
-// Returns the value of the removed element or nil if the list is empty.
+// Returns the value of the removed element and true, or false if the list is
+// empty.
 //
 // The complexity is O(1).
-func (ll *linkList[T]) Shift() *T {
+func (ll *List[T]) Shift() (T, bool) {
 	if ll.head == nil {
-		return nil
+		var zero T
+		return zero, false
 	}
-	ll.len--
-	ret := ll.head.value
-	if ll.head.next == nil {
-		ll.head = nil
-		ll.tail = nil
-		return &ret
-	}
-	ll.head = ll.head.next
-	ll.head.prev = nil
-	return &ret
+	return ll.RemoveElement(ll.head), true
 }
 
 // Remove removes an element for a zero based index.
 //
+// Negative indexes count back from the end of the list, -1 is the last
+// element.
+//
 // Given the index is not in the set of indexes no item will be removed.
 //
-// Returns the value of the removed element or nil if nothing is removed.
+// Returns the value of the removed element and true, or false if nothing is
+// removed.
 //
 // The complexity is O(n).
-func (ll *linkList[T]) Remove(index int) *T {
-	if index == 0 {
-		return ll.Shift()
-	}
-	if index == ll.Len()-1 {
-		return ll.Pop()
-	}
-
-	currentNode := ll.head
-	currentIndex := 0
-	for currentNode != nil {
-		if currentIndex == index {
-			prevNode := currentNode.prev
-			nextNode := currentNode.next
-			prevNode.next = nextNode
-			nextNode.prev = prevNode
-			return &currentNode.value
-		}
-		currentNode = currentNode.next
-		currentIndex++
-	}
-	return nil
+func (ll *List[T]) Remove(index int) (T, bool) {
+	value, err := ll.TryRemove(index)
+	return value, err == nil
 }
 
 // Pop removes the last element in the list.
 //
-// Returns the value of the removed element or nil if the list is empty.
+// Returns the value of the removed element and true, or false if the list is
+// empty.
 //
 // The complexity is O(1).
-func (ll *linkList[T]) Pop() *T {
-	if ll.head == nil {
-		return nil
+func (ll *List[T]) Pop() (T, bool) {
+	if ll.tail == nil {
+		var zero T
+		return zero, false
 	}
-	ll.len--
-	ret := ll.tail.value
-	if ll.head.next == nil {
-		ll.head = nil
-		ll.tail = nil
-		return &ret
-	}
-	ll.tail = ll.tail.prev
-	ll.tail.next = nil
-	return &ret
+	return ll.RemoveElement(ll.tail), true
 }
 
 // Swap swaps two elements in the list for two zero based indexes.
 //
+// Negative indexes count back from the end of the list, -1 is the last
+// element.
+//
 // Given indexA or indexB is not in the set of indexes no items will be swapped.
 //
 // Note this swaps values, but not references.
 //
 // The complexity is O(n).
-func (ll *linkList[T]) Swap(indexA, indexB int) {
-	currentNode := ll.head
-	currentIndex := 0
-	var nodeA *node[T]
-	var nodeB *node[T]
-	for currentNode != nil {
-		if currentIndex == indexA {
-			nodeA = currentNode
-		}
-		if currentIndex == indexB {
-			nodeB = currentNode
-		}
-		if nodeA != nil && nodeB != nil {
-			break
-		}
-		currentNode = currentNode.next
-		currentIndex++
-	}
-	if nodeA != nil && nodeB != nil {
-		nodeA.value, nodeB.value = nodeB.value, nodeA.value
-	}
-}
-
-// Get returns the value of an element in the list for a zero based index. If no
-// element matches the given index, nil is returned.
+func (ll *List[T]) Swap(indexA, indexB int) {
+	ll.TrySwap(indexA, indexB)
+}
+
+// Slice returns a new slice with the values of the list in order.
+//
+// The complexity is O(n).
+func (ll *List[T]) Slice() []T {
+	values := make([]T, 0, ll.len)
+	for e := ll.head; e != nil; e = e.next {
+		values = append(values, e.value)
+	}
+	return values
+}
+
+// Get returns the value of an element in the list for a zero based index and
+// true. If no element matches the given index, false is returned.
+//
+// Negative indexes count back from the end of the list, -1 is the last
+// element.
+//
+// The complexity is O(n)
+func (ll *List[T]) Get(index int) (T, bool) {
+	value, err := ll.At(index)
+	return value, err == nil
+}
+
+// Ref returns a pointer to the value of an element in the list for a zero based
+// index. If no element matches the given index, nil is returned.
+//
+// Negative indexes count back from the end of the list, -1 is the last
+// element.
+//
+// Writing through the pointer changes the value stored in the list. The
+// pointer keeps referring to the same element, so once that element is removed
+// writes no longer affect the list. Prefer Get unless the value needs to be
+// changed in place.
 //
 // The complexity is O(n)
-func (ll *linkList[T]) Get(index int) *T {
-	count := 0
-	currentNode := ll.head
-	for currentNode != nil {
-		if count == index {
-			return &currentNode.value
-		}
-		count++
-		currentNode = currentNode.next
-	}
-	return nil
+func (ll *List[T]) Ref(index int) *T {
+	i, err := ll.resolveIndex(index, ll.len)
+	if err != nil {
+		return nil
+	}
+	return &ll.elementAt(i).value
+}
+
+// insertBetween creates an element for value linked between prev and next.
+// A nil prev means the element becomes the head and a nil next means the
+// element becomes the tail.
+func (ll *List[T]) insertBetween(value T, prev, next *Element[T]) *Element[T] {
+	e := &Element[T]{
+		value: value,
+		owner: ll.id(),
+	}
+	ll.link(e, prev, next)
+	return e
+}
+
+// link links e between prev and next, which must be neighbors in the list.
+func (ll *List[T]) link(e, prev, next *Element[T]) {
+	e.prev = prev
+	e.next = next
+	if prev == nil {
+		ll.head = e
+	} else {
+		prev.next = e
+	}
+	if next == nil {
+		ll.tail = e
+	} else {
+		next.prev = e
+	}
+	ll.len++
+	ll.mods++
+}
+
+// unlink unlinks e from its neighbors. e still belongs to the list so it can be
+// linked again when it is being moved.
+func (ll *List[T]) unlink(e *Element[T]) {
+	if e.prev == nil {
+		ll.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		ll.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.prev = nil
+	e.next = nil
+	ll.len--
+	ll.mods++
+}
+
+// id returns the owner identifying the elements of the list.
+func (ll *List[T]) id() *owner {
+	if ll.owner == nil {
+		ll.owner = &owner{}
+	}
+	return ll.owner
+}
+
+// owns reports whether e is an element of the list.
+func (ll *List[T]) owns(e *Element[T]) bool {
+	return e.owner != nil && e.owner.resolve() == ll.id()
 }
@@ -1,6 +1,7 @@
 package list
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -22,6 +23,29 @@ func TestNew(t *testing.T) {
 	checkLen(t, l, 3)
 }
 
+func TestZeroValue(t *testing.T) {
+	var l List[int]
+	checkLen(t, &l, 0)
+	l.Append(2)
+	l.Prepend(1)
+	l.Insert(2, 3)
+	checkNodeValue(t, &l, 0, 1)
+	checkNodeValue(t, &l, 1, 2)
+	checkNodeValue(t, &l, 2, 3)
+	checkLen(t, &l, 3)
+}
+
+func TestNotComparable(t *testing.T) {
+	l := New([]int{1}, []int{2, 3})
+	l.Append([]int{4})
+	if r, ok := l.Get(1); !ok || len(r) != 2 {
+		t.Errorf("expected value at index 1 to be [2 3] got %v", r)
+	}
+	if l.Len() != 3 {
+		t.Errorf("expected len to be 3 got %v", l.Len())
+	}
+}
+
 func TestLen(t *testing.T) {
 
 	t.Run("prepend", func(t *testing.T) {
@@ -62,6 +86,26 @@ func TestLen(t *testing.T) {
 		checkLen(t, l, 0)
 	})
 
+	t.Run("insert middle", func(t *testing.T) {
+		l := New(1, 2, 3)
+
+		l.Insert(1, 4)
+		checkLen(t, l, 4)
+		l.Insert(2, 5)
+		checkLen(t, l, 5)
+		checkValid(t, l)
+	})
+
+	t.Run("remove middle", func(t *testing.T) {
+		l := New(1, 2, 3, 4)
+
+		l.Remove(1)
+		checkLen(t, l, 3)
+		l.Remove(1)
+		checkLen(t, l, 2)
+		checkValid(t, l)
+	})
+
 	t.Run("pop", func(t *testing.T) {
 		l := New(1, 2, 3)
 
@@ -77,7 +121,14 @@ func TestLen(t *testing.T) {
 	})
 }
 
-func checkLen(t *testing.T, l *linkList[int], expectedLen int) {
+func checkValid(t *testing.T, l *List[int]) {
+	t.Helper()
+	if err := l.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func checkLen(t *testing.T, l *List[int], expectedLen int) {
 	if len := l.Len(); len != expectedLen {
 		t.Errorf("expected len to be %v got %v", expectedLen, len)
 	}
@@ -167,34 +218,34 @@ func TestAppend(t *testing.T) {
 func TestShift(t *testing.T) {
 	l := New(1, 2, 3)
 
-	r := l.Shift()
-	checkEqual(t, r, 1)
+	r, ok := l.Shift()
+	checkEqual(t, r, ok, 1)
 	checkNodeValue(t, l, 0, 2)
 	checkNodeValue(t, l, 1, 3)
 	checkNodeNil(t, l, 2)
 
-	r = l.Shift()
-	checkEqual(t, r, 2)
+	r, ok = l.Shift()
+	checkEqual(t, r, ok, 2)
 	checkNodeValue(t, l, 0, 3)
 	checkNodeNil(t, l, 1)
 	checkNodeNil(t, l, 2)
 
-	r = l.Shift()
-	checkEqual(t, r, 3)
+	r, ok = l.Shift()
+	checkEqual(t, r, ok, 3)
 	checkNodeNil(t, l, 0)
 	checkNodeNil(t, l, 1)
 	checkNodeNil(t, l, 2)
 
-	r = l.Shift()
-	checkNil(t, r)
+	r, ok = l.Shift()
+	checkAbsent(t, r, ok)
 }
 
 func TestRemove(t *testing.T) {
 
 	t.Run("remove lower", func(t *testing.T) {
 		l := New(1, 2, 3)
-		r := l.Remove(0)
-		checkEqual(t, r, 1)
+		r, ok := l.Remove(0)
+		checkEqual(t, r, ok, 1)
 		checkNodeValue(t, l, 0, 2)
 		checkNodeValue(t, l, 1, 3)
 		checkNodeNil(t, l, 2)
@@ -202,8 +253,8 @@ func TestRemove(t *testing.T) {
 
 	t.Run("remove middle", func(t *testing.T) {
 		l := New(1, 2, 3)
-		r := l.Remove(1)
-		checkEqual(t, r, 2)
+		r, ok := l.Remove(1)
+		checkEqual(t, r, ok, 2)
 		checkNodeValue(t, l, 0, 1)
 		checkNodeValue(t, l, 1, 3)
 		checkNodeNil(t, l, 2)
@@ -211,8 +262,8 @@ func TestRemove(t *testing.T) {
 
 	t.Run("remove upper", func(t *testing.T) {
 		l := New(1, 2, 3)
-		r := l.Remove(2)
-		checkEqual(t, r, 3)
+		r, ok := l.Remove(2)
+		checkEqual(t, r, ok, 3)
 		checkNodeValue(t, l, 0, 1)
 		checkNodeValue(t, l, 1, 2)
 		checkNodeNil(t, l, 2)
@@ -220,34 +271,34 @@ func TestRemove(t *testing.T) {
 
 	t.Run("remove out of bounds", func(t *testing.T) {
 		l := New(1, 2, 3)
-		r := l.Remove(7)
-		checkNil(t, r)
+		r, ok := l.Remove(7)
+		checkAbsent(t, r, ok)
 	})
 }
 
 func TestPop(t *testing.T) {
 	l := New(1, 2, 3)
 
-	r := l.Pop()
-	checkEqual(t, r, 3)
+	r, ok := l.Pop()
+	checkEqual(t, r, ok, 3)
 	checkNodeValue(t, l, 0, 1)
 	checkNodeValue(t, l, 1, 2)
 	checkNodeNil(t, l, 2)
 
-	r = l.Pop()
-	checkEqual(t, r, 2)
+	r, ok = l.Pop()
+	checkEqual(t, r, ok, 2)
 	checkNodeValue(t, l, 0, 1)
 	checkNodeNil(t, l, 1)
 	checkNodeNil(t, l, 2)
 
-	r = l.Pop()
-	checkEqual(t, r, 1)
+	r, ok = l.Pop()
+	checkEqual(t, r, ok, 1)
 	checkNodeNil(t, l, 0)
 	checkNodeNil(t, l, 1)
 	checkNodeNil(t, l, 2)
 
-	r = l.Pop()
-	checkNil(t, r)
+	r, ok = l.Pop()
+	checkAbsent(t, r, ok)
 }
 
 func TestSwap(t *testing.T) {
@@ -296,22 +347,47 @@ func TestSwap(t *testing.T) {
 func TestGet(t *testing.T) {
 	t.Run("populated list", func(t *testing.T) {
 		l := New(1, 2, 3)
-		r := l.Get(0)
-		checkEqual(t, r, 1)
-		r = l.Get(1)
-		checkEqual(t, r, 2)
-		r = l.Get(2)
-		checkEqual(t, r, 3)
+		r, ok := l.Get(0)
+		checkEqual(t, r, ok, 1)
+		r, ok = l.Get(1)
+		checkEqual(t, r, ok, 2)
+		r, ok = l.Get(2)
+		checkEqual(t, r, ok, 3)
 	})
 
 	t.Run("empty list", func(t *testing.T) {
 		l := New[int]()
-		r := l.Get(1)
-		checkNil(t, r)
+		r, ok := l.Get(1)
+		checkAbsent(t, r, ok)
 	})
 }
 
-func checkNodeValue(t *testing.T, l *linkList[int], nodeIndex int, wantValue int) {
+func TestRef(t *testing.T) {
+	l := New(1, 2, 3)
+	*l.Ref(-1) = 4
+	checkNodeValue(t, l, 2, 4)
+	if r := l.Ref(3); r != nil {
+		t.Errorf("expected ref at index 3 to be nil got %v", *r)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	l := New(1, 2, 3)
+	s := l.Slice()
+	checkSlice(t, s, 1, 2, 3)
+	s[0] = 4
+	checkNodeValue(t, l, 0, 1)
+	checkSlice(t, New[int]().Slice())
+}
+
+func checkSlice(t *testing.T, got []int, want ...int) {
+	t.Helper()
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v got %v", want, got)
+	}
+}
+
+func checkNodeValue(t *testing.T, l *List[int], nodeIndex int, wantValue int) {
 	n := l.getNode(nodeIndex)
 	if n == nil {
 		t.Errorf("expected node value at index: %v, not to be nil", nodeIndex)
@@ -326,7 +402,7 @@ func checkNodeValue(t *testing.T, l *linkList[int], nodeIndex int, wantValue int
 	}
 }
 
-func checkNodePrev(t *testing.T, l *linkList[int], nodeIndex int, wantNode *node[int]) {
+func checkNodePrev(t *testing.T, l *List[int], nodeIndex int, wantNode *Element[int]) {
 	n := l.getNode(nodeIndex)
 	if n == nil {
 		t.Errorf("expected node value at index: %v, not to be nil", nodeIndex)
@@ -341,7 +417,7 @@ func checkNodePrev(t *testing.T, l *linkList[int], nodeIndex int, wantNode *node
 	}
 }
 
-func checkNodeNext(t *testing.T, l *linkList[int], nodeIndex int, wantNode *node[int]) {
+func checkNodeNext(t *testing.T, l *List[int], nodeIndex int, wantNode *Element[int]) {
 	n := l.getNode(nodeIndex)
 	if n == nil {
 		t.Errorf("expected node value at index: %v, not to be nil", nodeIndex)
@@ -356,26 +432,28 @@ func checkNodeNext(t *testing.T, l *linkList[int], nodeIndex int, wantNode *node
 	}
 }
 
-func checkNodeNil(t *testing.T, l *linkList[int], nodeIndex int) {
+func checkNodeNil(t *testing.T, l *List[int], nodeIndex int) {
 	n := l.getNode(nodeIndex)
 	if n != nil {
 		t.Errorf("expected node at index: %v to be nil", nodeIndex)
 	}
 }
 
-func checkEqual(t *testing.T, a *int, b int) {
-	if *a != b {
-		t.Errorf("expected %v to be equal to %b", a, b)
+func checkEqual(t *testing.T, got int, ok bool, want int) {
+	t.Helper()
+	if !ok || got != want {
+		t.Errorf("expected %v to be equal to %v", got, want)
 	}
 }
 
-func checkNil(t *testing.T, v *int) {
-	if v != nil {
-		t.Errorf("expected %v to be nil", v)
+func checkAbsent(t *testing.T, got int, ok bool) {
+	t.Helper()
+	if ok {
+		t.Errorf("expected no value got %v", got)
 	}
 }
 
-func (ll *linkList[T]) getNode(index int) *node[T] {
+func (ll *List[T]) getNode(index int) *Element[T] {
 	count := 0
 	currentNode := ll.head
 	for currentNode != nil {
@@ -0,0 +1,115 @@
+package list
+
+// NewRingDeque returns a deque backed by a ring buffer with room for capacity
+// values before it has to grow.
+//
+// Values are stored in a single slice, which avoids allocating for every value
+// the way a list does. Pushing is amortized O(1) as the buffer doubles when it
+// is full, every other operation is O(1).
+func NewRingDeque[T any](capacity int) Deque[T] {
+	return &ringDeque[T]{
+		values: make([]T, max(capacity, 1)),
+	}
+}
+
+// NewRingStack returns a stack backed by a ring buffer with room for capacity
+// values before it has to grow.
+func NewRingStack[T any](capacity int) Stack[T] {
+	return &dequeStack[T]{deque: NewRingDeque[T](capacity)}
+}
+
+// NewRingQueue returns a queue backed by a ring buffer with room for capacity
+// values before it has to grow.
+func NewRingQueue[T any](capacity int) Queue[T] {
+	return &dequeQueue[T]{deque: NewRingDeque[T](capacity)}
+}
+
+// ringDeque is a deque backed by a ring buffer. The values wrap around the end
+// of the buffer back to the start.
+type ringDeque[T any] struct {
+	values []T
+	// front is the index of the front value in values.
+	front int
+	// len is the count of values.
+	len int
+}
+
+func (d *ringDeque[T]) PushFront(value T) {
+	d.grow()
+	d.front = d.index(-1)
+	d.values[d.front] = value
+	d.len++
+}
+
+func (d *ringDeque[T]) PushBack(value T) {
+	d.grow()
+	d.values[d.index(d.len)] = value
+	d.len++
+}
+
+func (d *ringDeque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	value := d.values[d.front]
+	// Clear the slot so the buffer does not keep the value alive.
+	d.values[d.front] = zero
+	d.front = d.index(1)
+	d.len--
+	return value, true
+}
+
+func (d *ringDeque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	back := d.index(d.len - 1)
+	value := d.values[back]
+	d.values[back] = zero
+	d.len--
+	return value, true
+}
+
+func (d *ringDeque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.values[d.front], true
+}
+
+func (d *ringDeque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.values[d.index(d.len-1)], true
+}
+
+func (d *ringDeque[T]) Len() int {
+	return d.len
+}
+
+// index returns the buffer index of the value offset from the front.
+func (d *ringDeque[T]) index(offset int) int {
+	i := (d.front + offset) % len(d.values)
+	if i < 0 {
+		i += len(d.values)
+	}
+	return i
+}
+
+// grow doubles the buffer when it is full, unwrapping the values so the front
+// is at the start of the new buffer.
+func (d *ringDeque[T]) grow() {
+	if d.len < len(d.values) {
+		return
+	}
+	values := make([]T, len(d.values)*2)
+	n := copy(values, d.values[d.front:])
+	copy(values[n:], d.values[:d.front])
+	d.values = values
+	d.front = 0
+}
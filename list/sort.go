@@ -0,0 +1,111 @@
+package list
+
+import (
+	"cmp"
+)
+
+// Sort sorts the list in place by relinking its nodes so that values are in
+// the order defined by less.
+//
+// Sort is not guaranteed to be stable. Use SortStable when equal values must
+// keep their original order.
+//
+// The complexity is O(n log n).
+func (ll *List[T]) Sort(less func(a, b T) bool) {
+	ll.mergeSort(less)
+}
+
+// SortStable sorts the list in place like Sort while keeping the original order
+// of equal values.
+//
+// The complexity is O(n log n).
+func (ll *List[T]) SortStable(less func(a, b T) bool) {
+	ll.mergeSort(less)
+}
+
+// SortOrdered sorts a list of a naturally ordered type from least to greatest.
+// The sort is stable.
+//
+// The complexity is O(n log n).
+func SortOrdered[T cmp.Ordered](l *List[T]) {
+	l.mergeSort(cmp.Less[T])
+}
+
+// mergeSort is a bottom up merge sort. Each pass merges neighboring runs of
+// width nodes into runs of twice the width, following only next pointers. Once
+// the list is sorted the prev pointers and tail are restored in a final walk.
+//
+// The sort is stable and uses O(1) extra memory.
+func (ll *List[T]) mergeSort(less func(a, b T) bool) {
+	if ll.len < 2 {
+		return
+	}
+	head := ll.head
+	for width := 1; width < ll.len; width *= 2 {
+		var sortedHead, sortedTail *Element[T]
+		rest := head
+		for rest != nil {
+			left := rest
+			right := cut(left, width)
+			rest = cut(right, width)
+			mergedHead, mergedTail := merge(left, right, less)
+			if sortedTail == nil {
+				sortedHead = mergedHead
+			} else {
+				sortedTail.next = mergedHead
+			}
+			sortedTail = mergedTail
+		}
+		head = sortedHead
+	}
+
+	ll.head = head
+	ll.mods++
+	var prev *Element[T]
+	for n := head; n != nil; n = n.next {
+		n.prev = prev
+		prev = n
+	}
+	ll.tail = prev
+}
+
+// cut ends the run starting at n after count nodes and returns the node after
+// the run, or nil when there are no more nodes.
+func cut[T any](n *Element[T], count int) *Element[T] {
+	for i := 1; n != nil && i < count; i++ {
+		n = n.next
+	}
+	if n == nil {
+		return nil
+	}
+	rest := n.next
+	n.next = nil
+	return rest
+}
+
+// merge merges two sorted runs into a single sorted run and returns its first
+// and last nodes. When values are equal the value from a comes first, which is
+// what keeps the sort stable.
+func merge[T any](a, b *Element[T], less func(a, b T) bool) (*Element[T], *Element[T]) {
+	var start Element[T]
+	tail := &start
+	for a != nil && b != nil {
+		if less(b.value, a.value) {
+			tail.next = b
+			b = b.next
+		} else {
+			tail.next = a
+			a = a.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	for tail.next != nil {
+		tail = tail.next
+	}
+	return start.next, tail
+}
@@ -0,0 +1,75 @@
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	t.Run("unsorted", func(t *testing.T) {
+		l := New(5, 3, 9, 1, 7, 3, 8, 2, 6, 4, 0)
+		l.Sort(func(a, b int) bool { return a < b })
+		checkModel(t, l, []int{0, 1, 2, 3, 3, 4, 5, 6, 7, 8, 9})
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5)
+		l.Sort(func(a, b int) bool { return a > b })
+		checkModel(t, l, []int{5, 4, 3, 2, 1})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		l := New[int]()
+		l.Sort(func(a, b int) bool { return a < b })
+		checkModel(t, l, []int{})
+	})
+
+	t.Run("single", func(t *testing.T) {
+		l := New(1)
+		l.Sort(func(a, b int) bool { return a < b })
+		checkModel(t, l, []int{1})
+	})
+
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for n := 0; n < 100; n++ {
+			values := []int{}
+			for i := 0; i < n; i++ {
+				values = append(values, r.Intn(20))
+			}
+			l := New(values...)
+			SortOrdered(l)
+			slices.Sort(values)
+			checkModel(t, l, values)
+		}
+	})
+
+	t.Run("keeps nodes", func(t *testing.T) {
+		l := New(2, 1)
+		first, second := l.head, l.tail
+		SortOrdered(l)
+		if l.head != second || l.tail != first {
+			t.Error("expected nodes to be relinked instead of copied")
+		}
+	})
+}
+
+func TestSortStable(t *testing.T) {
+	type pair struct{ key, order int }
+	values := []pair{}
+	for i := 0; i < 50; i++ {
+		values = append(values, pair{key: (i * 7) % 5, order: i})
+	}
+	l := New(values...)
+	l.SortStable(func(a, b pair) bool { return a.key < b.key })
+	want := slices.Clone(values)
+	slices.SortStableFunc(want, func(a, b pair) int { return a.key - b.key })
+	i := 0
+	for n := l.head; n != nil; n = n.next {
+		if n.value != want[i] {
+			t.Fatalf("expected value at index %v to be %v got %v", i, want[i], n.value)
+		}
+		i++
+	}
+}
@@ -0,0 +1,140 @@
+package list
+
+// Concat moves every element of other to the end of the list, leaving other
+// empty. Handles to elements of other stay valid and now belong to the list.
+//
+// Given other is the list itself the list is not modified.
+//
+// The complexity is O(1).
+func (ll *List[T]) Concat(other *List[T]) {
+	if other == ll || other.head == nil {
+		return
+	}
+	if ll.tail == nil {
+		ll.head = other.head
+	} else {
+		ll.tail.next = other.head
+		other.head.prev = ll.tail
+	}
+	ll.tail = other.tail
+	ll.len += other.len
+	ll.mods++
+	if other.owner != nil {
+		other.owner.next = ll.id()
+		other.owner = nil
+	}
+	other.head = nil
+	other.tail = nil
+	other.len = 0
+	other.mods++
+}
+
+// SplitAt moves the elements of the list into two new lists. The first holds
+// the elements before the zero based index and the second holds the element at
+// the index and every element after it. The list is left empty.
+//
+// Given the index is not between 0 and Len inclusive nothing is split and nil
+// lists are returned.
+//
+// The complexity is O(n).
+func (ll *List[T]) SplitAt(index int) (*List[T], *List[T]) {
+	if index < 0 || ll.len < index {
+		return nil, nil
+	}
+	back := &List[T]{}
+	if index < ll.len {
+		back = ll.detach(ll.elementAt(index), ll.tail, ll.len-index)
+	}
+	// The elements left in the list move to the front list by handing over
+	// the owner, which keeps their handles valid without visiting them.
+	front := &List[T]{}
+	front.Concat(ll)
+	return front, back
+}
+
+// Sublist removes the elements from the zero based index from up to, but not
+// including, the zero based index to and returns them as a new list.
+//
+// Given from and to do not describe a range of the list nothing is removed and
+// nil is returned.
+//
+// The complexity is O(n).
+func (ll *List[T]) Sublist(from, to int) *List[T] {
+	if from < 0 || to < from || ll.len < to {
+		return nil
+	}
+	if from == to {
+		return &List[T]{}
+	}
+	return ll.detach(ll.elementAt(from), ll.elementAt(to-1), to-from)
+}
+
+// Reverse reverses the order of the list in place.
+//
+// The complexity is O(n).
+func (ll *List[T]) Reverse() {
+	for e := ll.head; e != nil; e = e.prev {
+		e.prev, e.next = e.next, e.prev
+	}
+	ll.head, ll.tail = ll.tail, ll.head
+	ll.mods++
+}
+
+// Rotate moves the last k elements of the list to the front. A negative k
+// moves the first -k elements to the back instead.
+//
+// Examples:
+//   - given [1, 2, 3, 4] Rotate(1) = [4, 1, 2, 3].
+//   - given [1, 2, 3, 4] Rotate(-1) = [2, 3, 4, 1].
+//
+// The complexity is O(n).
+func (ll *List[T]) Rotate(k int) {
+	if ll.len == 0 {
+		return
+	}
+	k %= ll.len
+	if k < 0 {
+		k += ll.len
+	}
+	if k == 0 {
+		return
+	}
+	newHead := ll.elementAt(ll.len - k)
+	// Close the list into a ring then open it before the new head.
+	ll.tail.next = ll.head
+	ll.head.prev = ll.tail
+	ll.tail = newHead.prev
+	ll.head = newHead
+	ll.tail.next = nil
+	ll.head.prev = nil
+	ll.mods++
+}
+
+// detach removes the count elements from first to last, inclusive, and
+// returns them as a new list.
+func (ll *List[T]) detach(first, last *Element[T], count int) *List[T] {
+	if first.prev == nil {
+		ll.head = last.next
+	} else {
+		first.prev.next = last.next
+	}
+	if last.next == nil {
+		ll.tail = first.prev
+	} else {
+		last.next.prev = first.prev
+	}
+	ll.len -= count
+	ll.mods++
+
+	first.prev = nil
+	last.next = nil
+	detached := &List[T]{
+		head: first,
+		tail: last,
+		len:  count,
+	}
+	for e := first; e != nil; e = e.next {
+		e.owner = detached.id()
+	}
+	return detached
+}
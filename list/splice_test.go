@@ -0,0 +1,177 @@
+package list
+
+import (
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	t.Run("populated", func(t *testing.T) {
+		a, b := New(1, 2), New(3, 4)
+		handle := b.Front()
+		a.Concat(b)
+		checkModel(t, a, []int{1, 2, 3, 4})
+		checkModel(t, b, []int{})
+
+		a.MoveToFront(handle)
+		checkModel(t, a, []int{3, 1, 2, 4})
+		b.MoveToFront(handle)
+		checkModel(t, a, []int{3, 1, 2, 4})
+		checkModel(t, b, []int{})
+	})
+
+	t.Run("into empty", func(t *testing.T) {
+		a, b := New[int](), New(1, 2)
+		a.Concat(b)
+		checkModel(t, a, []int{1, 2})
+		checkModel(t, b, []int{})
+	})
+
+	t.Run("empty other", func(t *testing.T) {
+		a := New(1, 2)
+		a.Concat(New[int]())
+		checkModel(t, a, []int{1, 2})
+	})
+
+	t.Run("self", func(t *testing.T) {
+		a := New(1, 2)
+		a.Concat(a)
+		checkModel(t, a, []int{1, 2})
+	})
+
+	t.Run("reuse other", func(t *testing.T) {
+		a, b := New(1), New(2)
+		old := b.Front()
+		a.Concat(b)
+		b.Append(3)
+		checkModel(t, b, []int{3})
+		if b.InsertAfter(4, old) != nil {
+			t.Error("expected an element moved by concat to be rejected by the emptied list")
+		}
+	})
+
+	t.Run("chained", func(t *testing.T) {
+		a, b, c := New(1), New(2), New(3)
+		handle := c.Front()
+		b.Concat(c)
+		a.Concat(b)
+		checkModel(t, a, []int{1, 2, 3})
+		a.RemoveElement(handle)
+		checkModel(t, a, []int{1, 2})
+	})
+}
+
+func TestSplitAt(t *testing.T) {
+	t.Run("middle", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5)
+		first, last := l.Front(), l.Back()
+		front, back := l.SplitAt(2)
+		checkModel(t, front, []int{1, 2})
+		checkModel(t, back, []int{3, 4, 5})
+		checkModel(t, l, []int{})
+
+		front.MoveToBack(first)
+		back.MoveToFront(last)
+		checkModel(t, front, []int{2, 1})
+		checkModel(t, back, []int{5, 3, 4})
+		front.MoveToFront(last)
+		checkModel(t, front, []int{2, 1})
+	})
+
+	t.Run("ends", func(t *testing.T) {
+		front, back := New(1, 2).SplitAt(0)
+		checkModel(t, front, []int{})
+		checkModel(t, back, []int{1, 2})
+
+		front, back = New(1, 2).SplitAt(2)
+		checkModel(t, front, []int{1, 2})
+		checkModel(t, back, []int{})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		l := New(1, 2)
+		front, back := l.SplitAt(3)
+		if front != nil || back != nil {
+			t.Error("expected nil lists")
+		}
+		checkModel(t, l, []int{1, 2})
+	})
+}
+
+func TestSublist(t *testing.T) {
+	t.Run("middle", func(t *testing.T) {
+		l := New(1, 2, 3, 4, 5)
+		s := l.Sublist(1, 4)
+		checkModel(t, s, []int{2, 3, 4})
+		checkModel(t, l, []int{1, 5})
+	})
+
+	t.Run("whole", func(t *testing.T) {
+		l := New(1, 2, 3)
+		s := l.Sublist(0, 3)
+		checkModel(t, s, []int{1, 2, 3})
+		checkModel(t, l, []int{})
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		l := New(1, 2, 3)
+		s := l.Sublist(1, 1)
+		checkModel(t, s, []int{})
+		checkModel(t, l, []int{1, 2, 3})
+	})
+
+	t.Run("out of bounds", func(t *testing.T) {
+		l := New(1, 2, 3)
+		if l.Sublist(2, 4) != nil || l.Sublist(2, 1) != nil || l.Sublist(-1, 1) != nil {
+			t.Error("expected nil lists")
+		}
+		checkModel(t, l, []int{1, 2, 3})
+	})
+
+	t.Run("handles", func(t *testing.T) {
+		l := New(1, 2, 3)
+		middle := l.Front().Next()
+		s := l.Sublist(1, 2)
+		l.MoveToFront(middle)
+		checkModel(t, l, []int{1, 3})
+		s.InsertAfter(4, middle)
+		checkModel(t, s, []int{2, 4})
+	})
+}
+
+func TestReverse(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	l.Reverse()
+	checkModel(t, l, []int{4, 3, 2, 1})
+
+	single := New(1)
+	single.Reverse()
+	checkModel(t, single, []int{1})
+
+	empty := New[int]()
+	empty.Reverse()
+	checkModel(t, empty, []int{})
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		k    int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4}},
+		{1, []int{4, 1, 2, 3}},
+		{3, []int{2, 3, 4, 1}},
+		{4, []int{1, 2, 3, 4}},
+		{6, []int{3, 4, 1, 2}},
+		{-1, []int{2, 3, 4, 1}},
+		{-5, []int{2, 3, 4, 1}},
+	}
+	for _, tt := range tests {
+		l := New(1, 2, 3, 4)
+		l.Rotate(tt.k)
+		checkModel(t, l, tt.want)
+	}
+
+	empty := New[int]()
+	empty.Rotate(2)
+	checkModel(t, empty, []int{})
+}
@@ -0,0 +1,61 @@
+package list
+
+import (
+	"fmt"
+)
+
+// Validate checks the links of the list and returns an error describing the
+// first problem found, or nil when the list is valid.
+//
+// The list is walked from head to tail and back checking that:
+//   - The head has no prev and the tail has no next.
+//   - Every next and prev pointer are mirrored by the neighboring element.
+//   - Every element belongs to the list.
+//   - The count of elements in both directions equals Len.
+//
+// The complexity is O(n).
+func (ll *List[T]) Validate() error {
+	if (ll.head == nil) != (ll.tail == nil) {
+		return fmt.Errorf("only one of head and tail is nil")
+	}
+	if ll.head != nil && ll.head.prev != nil {
+		return fmt.Errorf("head has a prev element")
+	}
+	if ll.tail != nil && ll.tail.next != nil {
+		return fmt.Errorf("tail has a next element")
+	}
+
+	count := 0
+	var last *Element[T]
+	for e := ll.head; e != nil; e = e.next {
+		if e.prev != last {
+			return fmt.Errorf("element %v: prev is not the element before it", count)
+		}
+		if !ll.owns(e) {
+			return fmt.Errorf("element %v: element does not belong to the list", count)
+		}
+		last = e
+		count++
+		if ll.len < count {
+			return fmt.Errorf("walking forward found more than len %v elements", ll.len)
+		}
+	}
+	if last != ll.tail {
+		return fmt.Errorf("walking forward ended at element %v which is not the tail", count-1)
+	}
+	if count != ll.len {
+		return fmt.Errorf("walking forward found %v elements, len is %v", count, ll.len)
+	}
+
+	count = 0
+	for e := ll.tail; e != nil; e = e.prev {
+		count++
+		if ll.len < count {
+			return fmt.Errorf("walking backward found more than len %v elements", ll.len)
+		}
+	}
+	if count != ll.len {
+		return fmt.Errorf("walking backward found %v elements, len is %v", count, ll.len)
+	}
+	return nil
+}
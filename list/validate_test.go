@@ -0,0 +1,72 @@
+package list
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		checkValid(t, New(1, 2, 3))
+		checkValid(t, New[int]())
+		checkValid(t, &List[int]{})
+	})
+
+	tests := []struct {
+		name    string
+		corrupt func(l *List[int])
+		want    string
+	}{
+		{
+			name:    "len too small",
+			corrupt: func(l *List[int]) { l.len-- },
+			want:    "walking forward found more than len 2 elements",
+		},
+		{
+			name:    "len too large",
+			corrupt: func(l *List[int]) { l.len++ },
+			want:    "walking forward found 3 elements, len is 4",
+		},
+		{
+			name:    "head prev",
+			corrupt: func(l *List[int]) { l.head.prev = l.tail },
+			want:    "head has a prev element",
+		},
+		{
+			name:    "tail next",
+			corrupt: func(l *List[int]) { l.tail.next = l.head },
+			want:    "tail has a next element",
+		},
+		{
+			name:    "asymmetric prev",
+			corrupt: func(l *List[int]) { l.tail.prev = l.head },
+			want:    "element 2: prev is not the element before it",
+		},
+		{
+			name:    "wrong tail",
+			corrupt: func(l *List[int]) { l.tail = l.head.next },
+			want:    "tail has a next element",
+		},
+		{
+			name:    "missing tail",
+			corrupt: func(l *List[int]) { l.tail = nil },
+			want:    "only one of head and tail is nil",
+		},
+		{
+			name: "foreign element",
+			corrupt: func(l *List[int]) {
+				l.head.next.owner = New(4).Front().owner
+			},
+			want: "element 1: element does not belong to the list",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(1, 2, 3)
+			tt.corrupt(l)
+			err := l.Validate()
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("expected error %q got %v", tt.want, err)
+			}
+		})
+	}
+}